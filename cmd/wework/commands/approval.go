@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/spinner"
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+// Outcomes of a booking besides wework.BookingConfirmed and wework.BookingPending.
+const (
+	bookingFailed   = "failed"
+	bookingRejected = "rejected"
+)
+
+const approvalPollInterval = 30 * time.Second
+
+// waitForApproval polls upcoming bookings until every accepted booking in results is confirmed
+// or rejected, or timeout passes. Bookings that report success are checked once as well, since
+// locations with approval turned on may still list them as pending. Only bookings that were
+// pending are marked rejected when they disappear; a confirmed booking missing from the list is
+// left for verifyBookings to report as unverified. Results are updated in place; if upcoming
+// bookings can't be fetched, they keep the status last seen and the error is returned.
+func waitForApproval(ww *wework.WeWork, jsonOut bool, results []*bookResult, timeout time.Duration) error {
+	var waiting []*bookResult
+	wasPending := make(map[*bookResult]bool)
+	for _, res := range results {
+		if res.Status == wework.BookingConfirmed || res.Status == wework.BookingPending {
+			waiting = append(waiting, res)
+			wasPending[res] = res.Status == wework.BookingPending
+		}
+	}
+	if len(waiting) == 0 {
+		return nil
+	}
+
+	poll := func(progress progressReporter) error {
+		deadline := time.Now().Add(timeout)
+		for {
+			progress.Update(fmt.Sprintf("Checking approval of %d booking(s)…", len(waiting)))
+			upcoming, err := ww.GetUpcomingBookings()
+			if err != nil {
+				return fmt.Errorf("failed to get upcoming bookings: %v", err)
+			}
+
+			var pending []*bookResult
+			for _, res := range waiting {
+				booking := findBooking(upcoming, *res)
+				switch {
+				case booking == nil && !wasPending[res]:
+					// Confirmed when booked; not being listed is for verification to report.
+				case booking == nil:
+					res.Status = bookingRejected
+					res.Error = "booking was rejected or cancelled while awaiting approval"
+				case booking.Status() == wework.BookingPending:
+					res.Status = wework.BookingPending
+					wasPending[res] = true
+					pending = append(pending, res)
+				default:
+					res.Status = wework.BookingConfirmed
+				}
+			}
+			waiting = pending
+
+			if len(waiting) == 0 || time.Now().Add(approvalPollInterval).After(deadline) {
+				return nil
+			}
+			progress.Update(fmt.Sprintf("Waiting for approval of %d booking(s)…", len(waiting)))
+			time.Sleep(approvalPollInterval)
+		}
+	}
+
+	if jsonOut {
+		return poll(quietProgress{})
+	}
+
+	err := spinner.WithContinuousSpinner(func(cs *spinner.ContinuousSpinner) error {
+		if err := poll(cs); err != nil {
+			return err
+		}
+		for _, res := range results {
+			switch res.Status {
+			case wework.BookingConfirmed:
+				cs.Printf("   ✓ %s: confirmed\n", res.Date)
+			case wework.BookingPending:
+				cs.Printf("   ⏳ %s: still pending after %s\n", res.Date, timeout)
+			case bookingRejected:
+				cs.Printf("   ❌ %s: %s\n", res.Date, res.Error)
+			}
+		}
+		cs.Success("Approval check finished")
+		return nil
+	})
+	return err
+}
+
+// approvalWarning describes a failed approval check; results keep the status last seen.
+func approvalWarning(err error) string {
+	return fmt.Sprintf("could not finish waiting for approval: %v; bookings are reported as last seen", err)
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+// parseGuest accepts "Name <email>", a bare email address or a bare name.
+func parseGuest(s string) (wework.Guest, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return wework.Guest{}, fmt.Errorf("empty --guest value")
+	}
+
+	if strings.Contains(s, "@") {
+		addr, err := mail.ParseAddress(s)
+		if err != nil {
+			return wework.Guest{}, fmt.Errorf("invalid guest email '%s': %v", s, err)
+		}
+		return wework.Guest{Name: addr.Name, Email: addr.Address}, nil
+	}
+	return wework.Guest{Name: s}, nil
+}
+
+// bookingOptions builds the seat count and guest list for a booking. Without --seats, one seat is
+// requested for the member plus one per guest.
+func bookingOptions(seats int, guestSpecs []string) (wework.BookingOptions, error) {
+	var opts wework.BookingOptions
+	for _, spec := range guestSpecs {
+		g, err := parseGuest(spec)
+		if err != nil {
+			return opts, err
+		}
+		opts.Guests = append(opts.Guests, g)
+	}
+
+	if seats < 0 {
+		return opts, fmt.Errorf("--seats must be positive")
+	}
+	if seats == 0 {
+		seats = 1 + len(opts.Guests)
+	}
+	if seats < 1+len(opts.Guests) {
+		return opts, fmt.Errorf("--seats %d is too few for yourself and %d guest(s)", seats, len(opts.Guests))
+	}
+	opts.Seats = seats
+	return opts, nil
+}
+
+// formatAttendees describes the seats and guests of a booking, or returns "" for a plain single-seat booking.
+func formatAttendees(seats int, guests []wework.Guest) string {
+	if seats <= 1 && len(guests) == 0 {
+		return ""
+	}
+	desc := fmt.Sprintf("%d seats", seats)
+	if len(guests) > 0 {
+		desc += ", guests: " + formatGuests(guests)
+	}
+	return desc
+}
+
+// formatGuests lists guests by name and email.
+func formatGuests(guests []wework.Guest) string {
+	var names []string
+	for _, g := range guests {
+		names = append(names, guestName(g))
+	}
+	return strings.Join(names, ", ")
+}
+
+func guestName(g wework.Guest) string {
+	switch {
+	case g.Name != "" && g.Email != "":
+		return fmt.Sprintf("%s <%s>", g.Name, g.Email)
+	case g.Name != "":
+		return g.Name
+	}
+	return g.Email
+}
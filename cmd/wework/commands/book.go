@@ -1,13 +1,17 @@
 package commands
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"os/signal"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/dvcrn/wework-cli/pkg/config"
 	"github.com/dvcrn/wework-cli/pkg/spinner"
-	"github.com/dvcrn/wework-cli/pkg/tzdate"
 	"github.com/dvcrn/wework-cli/pkg/wework"
 	"github.com/sahilm/fuzzy"
 	"github.com/spf13/cobra"
@@ -32,194 +36,689 @@ func FindLocationByFuzzyName(name string, locations []wework.GeoLocation) (strin
 	return locations[matches[0].Index].UUID, nil
 }
 
+// bookResult is the outcome of booking a single date.
+type bookResult struct {
+	Date          string                  `json:"date"`
+	EndDate       string                  `json:"endDate,omitempty"`
+	SpaceUUID     string                  `json:"spaceUUID"`
+	LocationUUID  string                  `json:"locationUUID"`
+	LocationName  string                  `json:"locationName"`
+	Fallbacks     []string                `json:"fallbacks,omitempty"`
+	Replaced      []string                `json:"replaced,omitempty"`
+	Seats         int                     `json:"seats"`
+	Guests        []wework.Guest          `json:"guests,omitempty"`
+	Note          string                  `json:"note,omitempty"`
+	StartTime     string                  `json:"startTime,omitempty"`
+	EndTime       string                  `json:"endTime,omitempty"`
+	TimeZone      string                  `json:"timeZone,omitempty"`
+	LocalTime     string                  `json:"localTime,omitempty"`
+	Credits       float64                 `json:"credits,omitempty"`
+	OverBudget    bool                    `json:"overBudget,omitempty"`
+	HorizonDays   int                     `json:"horizonDays,omitempty"`
+	BookableFrom  string                  `json:"bookableFrom,omitempty"`
+	Status        string                  `json:"status"`
+	BookingStatus *wework.BookingResponse `json:"booking,omitempty"`
+	Error         string                  `json:"error,omitempty"`
+	ErrorCode     string                  `json:"errorCode,omitempty"`
+	Hint          string                  `json:"hint,omitempty"`
+	Warning       string                  `json:"warning,omitempty"`
+
+	// horizon is set when the date was rejected as beyond the booking horizon
+	horizon *wework.HorizonError
+}
+
+// classify records the error code and hint for the API messages of a failed quote or booking,
+// and when date can be booked if it is beyond the booking horizon.
+func (res *bookResult) classify(date time.Time, messages ...string) {
+	berr := wework.ClassifyBookingError(messages...)
+	res.ErrorCode = berr.Code
+	res.Hint = berr.Hint
+	if berr.Code != wework.ErrCodeOutsideBookingWindow {
+		return
+	}
+
+	herr, ok := wework.ParseHorizon(messages...)
+	if !ok {
+		return
+	}
+	res.horizon = herr
+	res.HorizonDays = herr.Days
+	if herr.Days == 0 {
+		res.Hint = "Use --when-open --horizon DAYS to book it once bookings open"
+		return
+	}
+	res.BookableFrom = bookingOpensAt(date, herr.Days).Format("2006-01-02")
+	res.Hint = fmt.Sprintf("Bookable from %s; use --when-open to book it then", res.BookableFrom)
+}
+
+// bookDate books a space at the first of locationUUIDs with enough free seats on date,
+// cancelling the bookings in replace first. The quote is checked against budget before anything changes.
+func bookDate(ww *wework.WeWork, progress progressReporter, sel spaceSelector, date time.Time, locationUUIDs []string, replace []*wework.Booking, opts wework.BookingOptions, budget *creditBudget) bookResult {
+	dateStr := date.Format("2006-01-02")
+	res := bookResult{Date: dateStr, Seats: opts.SeatCount(), Guests: opts.Guests, Status: bookingFailed}
+
+	space, passed, err := findSpaceInOrder(ww, progress, sel, date, locationUUIDs)
+	res.Fallbacks = passed
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	opts.Note = renderNote(opts.Note, date, space)
+	res.Note = opts.Note
+	if err := res.setSpace(date, space, opts); err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	progress.Update(fmt.Sprintf("%s: fetching quote for %s…", dateStr, space.Location.Name))
+	quote, err := ww.GetBookingQuoteWithOptions(date, space, opts)
+	if err != nil {
+		res.Error = fmt.Sprintf("failed to get booking quote: %v", err)
+		res.classify(date, err.Error())
+		return res
+	}
+	placeBooking(ww, progress, &res, date, space, quote, replace, opts, budget)
+	return res
+}
+
+// setSpace records the space being booked and its booking window.
+func (res *bookResult) setSpace(date time.Time, space *wework.Workspace, opts wework.BookingOptions) error {
+	res.SpaceUUID = space.UUID
+	res.LocationUUID = space.Location.UUID
+	res.LocationName = space.Location.Name
+	if _, matched := wework.StrategyFor(space); !matched {
+		res.Warning = fmt.Sprintf("no booking strategy for account type %d, using default space IDs; see `wework debug strategy --location-uuid %s`", space.Location.AccountType, space.Location.UUID)
+	}
+
+	start, end, err := opts.Window(date, space)
+	if err != nil {
+		return fmt.Errorf("invalid booking window: %v", err)
+	}
+	res.StartTime = start.Format("15:04")
+	res.EndTime = end.Format("15:04 MST")
+	res.TimeZone = space.Location.TimeZone
+	res.LocalTime = localWindow(start, end)
+	return nil
+}
+
+// placeBooking checks quote against budget, cancels the bookings in replace and books space on
+// date with quote, recording the outcome in res.
+func placeBooking(ww *wework.WeWork, progress progressReporter, res *bookResult, date time.Time, space *wework.Workspace, quote *wework.QuoteResponse, replace []*wework.Booking, opts wework.BookingOptions, budget *creditBudget) {
+	dateStr := date.Format("2006-01-02")
+	res.Credits = quote.GrandTotal.Amount
+	days := spanDays(date, opts.EndDate)
+	// The bookings being replaced are cancelled first, so they don't count against the budget
+	if err := budget.without(replace).checkDays(days, res.Credits); err != nil {
+		res.Error = err.Error()
+		res.OverBudget = true
+		return
+	}
+
+	for _, existing := range replace {
+		progress.Update(fmt.Sprintf("%s: cancelling existing booking %s…", dateStr, existing.UUID))
+		if _, err := ww.CancelBooking(existing); err != nil {
+			res.Error = fmt.Sprintf("failed to cancel existing booking %s: %v", existing.UUID, err)
+			return
+		}
+		res.Replaced = append(res.Replaced, existing.UUID)
+		budget.release([]*wework.Booking{existing})
+	}
+
+	progress.Update(fmt.Sprintf("%s: creating booking at %s…", dateStr, space.Location.Name))
+	bookRes, err := ww.PostBookingWithQuote(date, space, quote, opts)
+	if err != nil {
+		res.Error = fmt.Sprintf("booking failed: %v", err)
+		res.classify(date, err.Error())
+		return
+	}
+	res.BookingStatus = bookRes
+
+	switch {
+	case bookRes.IsSuccess():
+		res.Status = wework.BookingConfirmed
+	case bookRes.IsPending():
+		res.Status = wework.BookingPending
+	default:
+		var errMsg strings.Builder
+		errMsg.WriteString(fmt.Sprintf("booking failed: %s", bookRes.BookingStatus))
+		for _, e := range bookRes.Errors {
+			errMsg.WriteString(fmt.Sprintf("\n  %s", e))
+		}
+		res.classify(date, bookRes.ErrorMessages()...)
+		res.Error = errMsg.String()
+		return
+	}
+	budget.commitDays(days, res.Credits)
+}
+
+// bookDateWithProgress runs bookDate, showing a spinner and the outcome in text mode.
+func bookDateWithProgress(ww *wework.WeWork, jsonOut bool, sel spaceSelector, date time.Time, locationUUIDs []string, replace []*wework.Booking, opts wework.BookingOptions, budget *creditBudget) bookResult {
+	return withBookProgress(jsonOut, func(progress progressReporter) bookResult {
+		return bookDate(ww, progress, sel, date, locationUUIDs, replace, opts, budget)
+	})
+}
+
+// withBookProgress runs book, showing a spinner and the outcome in text mode.
+func withBookProgress(jsonOut bool, book func(progressReporter) bookResult) bookResult {
+	if jsonOut {
+		return book(quietProgress{})
+	}
+
+	// Text mode: drive spinner updates during network calls
+	var res bookResult
+	err := spinner.WithContinuousSpinner(func(cs *spinner.ContinuousSpinner) error {
+		res = book(cs)
+		return reportBookResult(cs, res)
+	})
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	return res
+}
+
+// reportBookResult shows the outcome of a booking on the spinner, returning the failure as an error.
+func reportBookResult(cs *spinner.ContinuousSpinner, res bookResult) error {
+	for _, f := range res.Fallbacks {
+		cs.Printf("   passed over %s\n", f)
+	}
+	if res.Warning != "" {
+		cs.Printf("   ⚠️  %s\n", res.Warning)
+	}
+	if res.Error != "" {
+		if res.Hint != "" {
+			return fmt.Errorf("%s: %s\n   → %s", res.dateRange(), res.Error, res.Hint)
+		}
+		return fmt.Errorf("%s: %s", res.dateRange(), res.Error)
+	}
+	msg := fmt.Sprintf("Booking successful for %s at %s! Reservation ID: %s", res.dateRange(), res.LocationName, res.BookingStatus.ReservationID)
+	if res.Status == wework.BookingPending {
+		msg = fmt.Sprintf("Booking for %s at %s is pending approval (%s). Reservation ID: %s", res.dateRange(), res.LocationName, res.BookingStatus.BookingStatus, res.BookingStatus.ReservationID)
+	}
+	if attendees := formatAttendees(res.Seats, res.Guests); attendees != "" {
+		msg += fmt.Sprintf(" (%s)", attendees)
+	}
+	if res.LocalTime != "" {
+		msg += fmt.Sprintf("\n   %s ~ %s at the location, %s local time", res.StartTime, res.EndTime, res.LocalTime)
+	}
+	cs.Success(msg)
+	return nil
+}
+
+// verifyOverride returns the value of --verify when it was given, or nil to use the default.
+func verifyOverride(cmd *cobra.Command, verify bool) *bool {
+	if !cmd.Flags().Changed("verify") {
+		return nil
+	}
+	return &verify
+}
+
+// loadUpcomingBookings fetches upcoming bookings, with a spinner in text mode.
+func loadUpcomingBookings(ww *wework.WeWork, jsonOut bool) ([]*wework.Booking, error) {
+	if jsonOut {
+		upcoming, err := ww.GetUpcomingBookings()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get upcoming bookings: %v", err)
+		}
+		return upcoming, nil
+	}
+
+	var upcoming []*wework.Booking
+	err := spinner.WithContinuousSpinner(func(cs *spinner.ContinuousSpinner) error {
+		cs.Update("Checking existing bookings…")
+		r, err := ww.GetUpcomingBookings()
+		if err != nil {
+			return fmt.Errorf("failed to get upcoming bookings: %v", err)
+		}
+		upcoming = r
+		cs.Success("Checked existing bookings")
+		return nil
+	})
+	return upcoming, err
+}
+
 func NewBookCommand(authenticate func() (*wework.WeWork, error)) *cobra.Command {
-	var locationUUID, city, name, date string
+	var locationUUID, city, name, spaceUUID, pick string
+	var dateOpts dateOptions
+	var onConflict string
+	var dryRun bool
+	var seats int
+	var guests []string
+	var note string
+	var maxPerDay, maxTotal float64
+	var overBudget string
+	var planPath string
+	var atomic bool
+	var waitApproval bool
+	var verifyFlag bool
+	var whenOpen bool
+	var multiday bool
+	var segmentValues []string
+	var company, membership string
+	var quoteRef string
+	var priceTolerance float64
+	var horizon int
+	var approvalTimeout time.Duration
 	cmd := &cobra.Command{
 		Use:   "book",
 		Short: "Book a workspace",
 		Long:  `Book a workspace at a WeWork location.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOut, _ := cmd.Flags().GetBool("json")
+
+			selector, err := newSpaceSelector(spaceUUID, pick, jsonOut)
+			if err != nil {
+				return usageError(err)
+			}
+
+			opts, err := bookingOptions(seats, guests)
+			if err != nil {
+				return usageError(err)
+			}
+			selector.Seats = opts.Seats
+
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			profile, err := loadProfile(cmd, cfg)
+			if err != nil {
+				return usageError(err)
+			}
+			opts.Note = noteTemplate(note, profile)
+
+			if err := validateOverBudget(overBudget); err != nil {
+				return usageError(err)
+			}
+			budget, err := newCreditBudget(maxPerDay, maxTotal, cfg.MonthlyCreditBudget)
+			if err != nil {
+				return usageError(err)
+			}
+
+			if err := validateOnConflict(onConflict); err != nil {
+				return usageError(err)
+			}
+			if atomic && onConflict == onConflictReplace {
+				return usageError(fmt.Errorf("--atomic cannot be combined with --on-conflict replace, replaced bookings could not be restored on rollback"))
+			}
+
+			if whenOpen && (dryRun || atomic || planPath != "") {
+				return usageError(fmt.Errorf("--when-open cannot be combined with --dry-run, --atomic or --plan"))
+			}
+			if multiday && (whenOpen || dryRun || atomic || planPath != "") {
+				return usageError(fmt.Errorf("--multiday cannot be combined with --when-open, --dry-run, --atomic or --plan"))
+			}
+			segments, err := parseSegments(segmentValues)
+			if err != nil {
+				return usageError(err)
+			}
+			if len(segments) > 0 {
+				if locationUUID != "" || city != "" || name != "" || spaceUUID != "" {
+					return usageError(fmt.Errorf("--segment cannot be combined with --location-uuid, --city, --name or --space-uuid, each segment names its location"))
+				}
+				if multiday || whenOpen || dryRun || atomic || planPath != "" {
+					return usageError(fmt.Errorf("--segment cannot be combined with --multiday, --when-open, --dry-run, --atomic or --plan"))
+				}
+			}
+			if horizon < 0 {
+				return usageError(fmt.Errorf("--horizon must not be negative"))
+			}
+			if planPath != "" {
+				if err := checkPlanFlags(cmd); err != nil {
+					return usageError(err)
+				}
+			}
+
+			var saved *wework.SavedQuote
+			var quoteDay time.Time
+			if quoteRef != "" {
+				if err := checkQuoteFlags(cmd); err != nil {
+					return usageError(err)
+				}
+				if saved, err = loadQuote(quoteRef); err != nil {
+					return usageError(err)
+				}
+				if quoteDay, err = saved.Day(); err != nil {
+					return err
+				}
+			}
+			if priceTolerance < 0 {
+				return usageError(fmt.Errorf("--price-tolerance must not be negative"))
+			}
+			if saved == nil && planPath == "" {
+				if len(segments) == 0 && locationUUID == "" && (name == "" || city == "") {
+					return usageError(fmt.Errorf("--location-uuid OR (--city + --name) is required for booking"))
+				}
+				if err := dateOpts.validate(); err != nil {
+					return usageError(err)
+				}
+			}
+
 			ww, err := authenticate()
 			if err != nil {
 				return err
 			}
 
-			if locationUUID == "" && (name == "" || city == "") {
-				return fmt.Errorf("--location-uuid OR (--city + --name) is required for booking")
+			payer, err := selectPayer(ww, jsonOut, company, membership)
+			if err != nil {
+				return err
 			}
+			opts = withPayer(opts, payer)
 
-			jsonOut, _ := cmd.Flags().GetBool("json")
+			if planPath != "" {
+				runner := &planRunner{
+					ww:         ww,
+					cmd:        cmd,
+					cfg:        cfg,
+					sel:        selector,
+					exclusions: dateOpts,
+					onConflict: onConflict,
+					overBudget: overBudget,
+					budget:     budget,
+					dryRun:     dryRun,
+					atomic:     atomic,
+					jsonOut:    jsonOut,
+					payer:      payer,
 
-			// Find target location UUID
-			var targetLocationUUID string
-			if jsonOut {
+					waitApproval:    waitApproval,
+					approvalTimeout: approvalTimeout,
+					verify:          verifyOverride(cmd, verifyFlag),
+				}
+				return runner.run(planPath)
+			}
+
+			// Find target location UUIDs, in order of preference, and the days they are closed
+			var targetLocationUUIDs []string
+			var closed map[time.Weekday]bool
+			var closedErr error
+			if saved != nil {
+				targetLocationUUIDs = []string{saved.LocationUUID}
+			} else if len(segments) > 0 {
+				if closed, closedErr, err = resolveSegmentsWithProgress(ww, cfg, jsonOut, segments); err != nil {
+					return err
+				}
+				// Dates are interpreted in the timezone of the first segment's location
+				targetLocationUUIDs = segments[0].locationUUIDs
+			} else if jsonOut {
 				// JSON: resolve without spinner
-				t, err := resolveLocationUUID(ww, city, name, locationUUID)
+				t, err := resolveLocationUUIDs(ww, cfg, city, name, locationUUID)
 				if err != nil {
 					return err
 				}
-				targetLocationUUID = t
+				targetLocationUUIDs = t
+				closed, closedErr = closedWeekdays(ww, targetLocationUUIDs)
 			} else {
 				if err := spinner.WithContinuousSpinner(func(cs *spinner.ContinuousSpinner) error {
 					cs.Update("Resolving location…")
-					t, err := resolveLocationUUID(ww, city, name, locationUUID)
+					t, err := resolveLocationUUIDs(ww, cfg, city, name, locationUUID)
 					if err != nil {
 						return err
 					}
-					targetLocationUUID = t
+					targetLocationUUIDs = t
+					cs.Update("Checking opening hours…")
+					closed, closedErr = closedWeekdays(ww, targetLocationUUIDs)
 					cs.Success("Location resolved")
 					return nil
 				}); err != nil {
 					return err
 				}
 			}
-
-			// Parse dates
-			dates := []time.Time{}
-			if strings.Contains(date, "~") {
-				// Date range
-				parts := strings.Split(date, "~")
-				if len(parts) != 2 {
-					return fmt.Errorf("invalid date range format. Use YYYY-MM-DD~YYYY-MM-DD")
+			// Failing to read opening hours only means closed days aren't skipped
+			var warnings []string
+			if closedErr != nil {
+				warning := fmt.Sprintf("%v; closed days will not be skipped", closedErr)
+				if jsonOut {
+					warnings = append(warnings, warning)
+				} else {
+					fmt.Printf("⚠️  %s\n", warning)
 				}
+			}
 
-				startDate, err := tzdate.ParseInTimezone("2006-01-02", strings.TrimSpace(parts[0]), "Local")
-				if err != nil {
-					return fmt.Errorf("invalid start date: %v", err)
+			// A saved quote is for a single, known date
+			dates := []time.Time{quoteDay}
+			var skipped []skippedDate
+			if saved == nil {
+				if dates, err = dateOpts.resolveAt(ww, targetLocationUUIDs); err != nil {
+					return usageError(err)
 				}
-
-				endDate, err := tzdate.ParseInTimezone("2006-01-02", strings.TrimSpace(parts[1]), "Local")
-				if err != nil {
-					return fmt.Errorf("invalid end date: %v", err)
+				if dates, skipped, err = dateOpts.exclude(dates, closed); err != nil {
+					return usageError(err)
 				}
+			}
 
-				for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
-					dates = append(dates, d)
-				}
-			} else if strings.Contains(date, ",") {
-				// Comma-separated list
-				for _, d := range strings.Split(date, ",") {
-					parsed, err := tzdate.ParseInTimezone("2006-01-02", strings.TrimSpace(d), "Local")
-					if err != nil {
-						return fmt.Errorf("invalid date format: %v", err)
-					}
-					dates = append(dates, parsed)
+			// Load existing reservations so re-runs don't try to book the same day twice
+			upcoming, err := loadUpcomingBookings(ww, jsonOut)
+			if err != nil {
+				return err
+			}
+			if err := budget.loadUsage(ww, upcoming); err != nil {
+				return err
+			}
+
+			existing := bookingsByDate(upcoming)
+			switch onConflict {
+			case onConflictSkip:
+				var conflicts []skippedDate
+				dates, conflicts = splitConflicts(dates, existing)
+				skipped = append(skipped, conflicts...)
+			case onConflictFail:
+				if _, conflicts := splitConflicts(dates, existing); len(conflicts) > 0 {
+					return fmt.Errorf("dates already booked: %s", formatSkipped(conflicts))
 				}
-			} else {
-				// Single date
-				parsed, err := tzdate.ParseInTimezone("2006-01-02", strings.TrimSpace(date), "Local")
-				if err != nil {
-					return fmt.Errorf("invalid date format: %v", err)
+			}
+
+			if !jsonOut {
+				for _, s := range skipped {
+					fmt.Printf("⏭  %s: %s, skipping\n", s.Date, s.Reason)
 				}
-				dates = append(dates, parsed)
 			}
 
-			if jsonOut {
-				// JSON mode: compute results for all dates and emit once
-				type resultRow struct {
-					Date          string                  `json:"date"`
-					SpaceUUID     string                  `json:"spaceUUID"`
-					LocationUUID  string                  `json:"locationUUID"`
-					LocationName  string                  `json:"locationName"`
-					BookingStatus *wework.BookingResponse `json:"booking,omitempty"`
-					Error         string                  `json:"error,omitempty"`
+			// Projections leave out the bookings that replacing would cancel
+			projected := budget
+			if onConflict == onConflictReplace {
+				projected = budget.without(replacementsFor(existing, dates, nil))
+			}
+
+			if dryRun {
+				return runBookDryRun(ww, jsonOut, selector, dates, targetLocationUUIDs, skipped, opts, projected)
+			}
+
+			if atomic && len(dates) > 0 {
+				// Check every date before booking any, all at the same location
+				locationUUID, problems := chooseAtomicLocation(ww, jsonOut, selector, dates, targetLocationUUIDs, opts, projected)
+				if len(problems) > 0 {
+					return &ExitError{Code: ExitAllFailed, Err: fmt.Errorf("atomic booking aborted, nothing was booked:\n  %s", strings.Join(problems, "\n  "))}
 				}
-				var results []resultRow
+				targetLocationUUIDs = []string{locationUUID}
+			}
 
-				for _, bookingDate := range dates {
-					row := resultRow{Date: bookingDate.Format("2006-01-02")}
+			ctx := context.Background()
+			if whenOpen && len(dates) > 0 {
+				// Earlier dates open first, so waiting in input order could miss them
+				sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+				if horizon == 0 {
+					if horizon, err = detectHorizonWithProgress(ww, jsonOut, selector, dates, targetLocationUUIDs, opts); err != nil {
+						return err
+					}
+				}
+				var stop context.CancelFunc
+				ctx, stop = signal.NotifyContext(ctx, os.Interrupt)
+				defer stop()
+			}
 
-					spaces, err := ww.GetAvailableSpaces(bookingDate, []string{targetLocationUUID})
-					if err != nil {
-						row.Error = fmt.Sprintf("error getting spaces: %v", err)
-						results = append(results, row)
+			var results []bookResult
+			var rolledBack []rollbackResult
+			var atomicFailed bool
+			perDay := dates
+			if multiday {
+				// Book each run of consecutive dates as one reservation where the location allows it
+				perDay = nil
+				for _, run := range consecutiveRuns(dates) {
+					if len(run) == 1 || (len(results) > 0 && results[len(results)-1].OverBudget && overBudget == overBudgetAbort) {
+						perDay = append(perDay, run...)
 						continue
 					}
-
-					if len(spaces.Response.Workspaces) == 0 {
-						row.Error = "no spaces found"
-						results = append(results, row)
+					var replace []*wework.Booking
+					if onConflict == onConflictReplace {
+						replace = replacementsFor(existing, run, results)
+					}
+					res, ok := bookMultidayWithProgress(ww, jsonOut, selector, run, targetLocationUUIDs, replace, opts, budget)
+					if !ok {
+						perDay = append(perDay, run...)
 						continue
 					}
+					results = append(results, res)
+				}
+			}
+			for i, bookingDate := range perDay {
+				if n := len(results); n > 0 && results[n-1].OverBudget && overBudget == overBudgetAbort {
+					for _, d := range perDay[i:] {
+						skipped = append(skipped, skippedDate{Date: d.Format("2006-01-02"), Reason: "not attempted, budget exceeded"})
+					}
+					if !jsonOut {
+						fmt.Printf("⛔ Budget exceeded, not booking the remaining %d date(s)\n", len(perDay)-i)
+					}
+					break
+				}
 
-					if len(spaces.Response.Workspaces) > 1 {
-						row.Error = "multiple spaces found, please specify a specific space"
-						results = append(results, row)
-						continue
+				var replace []*wework.Booking
+				if onConflict == onConflictReplace {
+					replace = replacementsFor(existing, []time.Time{bookingDate}, results)
+				}
+
+				if len(segments) > 0 {
+					// Book each part of the split day on its own; a budget stop ends the day too
+					for _, seg := range segments {
+						if n := len(results); n > 0 && results[n-1].OverBudget && overBudget == overBudgetAbort {
+							break
+						}
+						if onConflict == onConflictReplace {
+							replace = replacementsFor(existing, []time.Time{bookingDate}, results)
+						}
+						results = append(results, bookDateWithProgress(ww, jsonOut, selector, bookingDate, seg.locationUUIDs, replace, seg.options(opts), budget))
 					}
+					continue
+				}
 
-					space := spaces.Response.Workspaces[0]
-					row.SpaceUUID = space.UUID
-					row.LocationUUID = space.Location.UUID
-					row.LocationName = space.Location.Name
+				var res bookResult
+				if saved != nil {
+					res = withBookProgress(jsonOut, func(progress progressReporter) bookResult {
+						return bookQuoted(ww, progress, saved, bookingDate, opts.Note, replace, budget, priceTolerance)
+					})
+				} else if whenOpen {
+					res = bookWhenOpen(ctx, ww, jsonOut, selector, bookingDate, targetLocationUUIDs, replace, opts, budget, horizon)
+				} else {
+					res = bookDateWithProgress(ww, jsonOut, selector, bookingDate, targetLocationUUIDs, replace, opts, budget)
+				}
+				results = append(results, res)
+				if ctx.Err() != nil {
+					for _, d := range perDay[i+1:] {
+						skipped = append(skipped, skippedDate{Date: d.Format("2006-01-02"), Reason: "not attempted, interrupted"})
+					}
+					break
+				}
+				if atomic && res.Error != "" {
+					atomicFailed = true
+					for _, d := range perDay[i+1:] {
+						skipped = append(skipped, skippedDate{Date: d.Format("2006-01-02"), Reason: "not attempted, atomic booking failed"})
+					}
+					rolledBack = rollbackBookings(ww, jsonOut, results)
+					break
+				}
+			}
 
-					bookRes, err := ww.PostBooking(bookingDate, &space)
-					if err != nil {
-						row.Error = fmt.Sprintf("booking failed: %v", err)
+			var accepted []*bookResult
+			for i := range results {
+				accepted = append(accepted, &results[i])
+			}
+			if !atomicFailed && shouldVerify(verifyOverride(cmd, verifyFlag), len(dates)) {
+				if err := verifyBookings(ww, jsonOut, accepted); err != nil {
+					warning := verifyWarning(err)
+					if jsonOut {
+						warnings = append(warnings, warning)
+					} else {
+						fmt.Printf("⚠️  %s\n", warning)
+					}
+				}
+			}
+			if waitApproval && !atomicFailed {
+				// Bookings stay as last seen if polling fails, and are still reported
+				if err := waitForApproval(ww, jsonOut, accepted, approvalTimeout); err != nil {
+					warning := approvalWarning(err)
+					if jsonOut {
+						warnings = append(warnings, warning)
 					} else {
-						row.BookingStatus = bookRes
+						fmt.Printf("⚠️  %s\n", warning)
 					}
-					results = append(results, row)
 				}
+			}
 
-				b, err := json.MarshalIndent(results, "", "  ")
+			summary := summarize(results, skipped)
+			summary.rollBack(rolledBack)
+			if jsonOut {
+				payload := map[string]any{"results": results, "skipped": skipped, "summary": summary}
+				if budget.limited() {
+					payload["budget"] = budget.report(dates)
+				}
+				if atomic {
+					payload["rolledBack"] = rolledBack
+				}
+				if len(warnings) > 0 {
+					payload["warnings"] = warnings
+				}
+				b, err := json.MarshalIndent(payload, "", "  ")
 				if err != nil {
 					return fmt.Errorf("failed to marshal JSON: %v", err)
 				}
 				fmt.Println(string(b))
 			} else {
-				// Text mode: drive spinner updates during network calls for each date
-				for _, bookingDate := range dates {
-					dateStr := bookingDate.Format("2006-01-02")
-					err := spinner.WithContinuousSpinner(func(cs *spinner.ContinuousSpinner) error {
-						cs.Update(fmt.Sprintf("%s: finding available spaces…", dateStr))
-
-						spaces, err := ww.GetAvailableSpaces(bookingDate, []string{targetLocationUUID})
-						if err != nil {
-							return fmt.Errorf("%s: error getting spaces: %v", dateStr, err)
-						}
-						if len(spaces.Response.Workspaces) == 0 {
-							return fmt.Errorf("%s: no spaces found", dateStr)
-						}
-						if len(spaces.Response.Workspaces) > 1 {
-							return fmt.Errorf("%s: multiple spaces found, please specify a specific space", dateStr)
-						}
-
-						space := spaces.Response.Workspaces[0]
-						cs.Update(fmt.Sprintf("%s: creating booking at %s…", dateStr, space.Location.Name))
-
-						bookRes, err := ww.PostBooking(bookingDate, &space)
-						if err != nil {
-							return fmt.Errorf("%s: booking failed: %v", dateStr, err)
-						}
-
-						if bookRes.BookingStatus != "BookingSuccess" {
-							var errMsg strings.Builder
-							errMsg.WriteString(fmt.Sprintf("%s: booking failed: %s", dateStr, bookRes.BookingStatus))
-							for _, e := range bookRes.Errors {
-								errMsg.WriteString(fmt.Sprintf("\n  %s", e))
-							}
-							return fmt.Errorf(errMsg.String())
-						}
-
-						cs.Success(fmt.Sprintf("Booking successful for %s! Reservation ID: %s", dateStr, bookRes.ReservationID))
-						return nil
-					})
+				if len(skipped) > 0 {
+					fmt.Printf("\nSkipped %d date(s): %s\n", len(skipped), formatSkipped(skipped))
+				}
+				if budget.limited() {
+					fmt.Printf("\n%s\n", budget.report(dates).format("Spent"))
+				}
+				fmt.Printf("\n%s\n", summary)
+			}
 
-					if err != nil {
-						fmt.Printf("❌ %v\n", err)
-					}
+			if atomicFailed {
+				if rollbackFailed(rolledBack) {
+					return &ExitError{Code: ExitPartial, Err: fmt.Errorf("atomic booking failed and the rollback is incomplete, check your bookings")}
 				}
+				return &ExitError{Code: ExitAllFailed, Err: fmt.Errorf("atomic booking failed, rolled back %d booking(s)", len(rolledBack))}
 			}
 
-			return nil
+			return summary.err()
 		},
 	}
 
-	cmd.Flags().StringVar(&locationUUID, "location-uuid", "", "Location UUID for booking")
+	cmd.Flags().StringVar(&locationUUID, "location-uuid", "", "Location UUID for booking; a comma-separated list or a named list from the config tries locations in order until one has a free seat")
 	cmd.Flags().StringVar(&city, "city", "", "City name")
 	cmd.Flags().StringVar(&name, "name", "", "Space name")
-	cmd.Flags().StringVar(&date, "date", time.Now().Format("2006-01-02"), "Date in YYYY-MM-DD format")
+	dateOpts.addFlags(cmd)
+	cmd.Flags().StringVar(&spaceUUID, "space-uuid", "", "Workspace UUID to book when a location has multiple spaces")
+	cmd.Flags().StringVar(&pick, "pick", "", "Strategy when multiple spaces are found: first, most-available or cheapest (default: prompt on a TTY)")
+	cmd.Flags().StringVar(&onConflict, "on-conflict", onConflictSkip, "What to do with dates that already have a booking: skip, fail or replace")
+	cmd.Flags().IntVar(&seats, "seats", 0, "Number of seats to book, including your own (default: 1 plus one per guest)")
+	cmd.Flags().StringArrayVar(&guests, "guest", nil, "Guest to bring, as a name, an email or \"Name <email>\" (repeatable)")
+	cmd.Flags().StringVar(&note, "note", "", "Note to attach to the booking; supports {date}, {weekday}, {location}, {city} and profile variables (default: the profile's note)")
+	cmd.Flags().Float64Var(&maxPerDay, "max-credits-per-day", 0, "Don't spend more than this many credits on any one date, counting all its segments")
+	cmd.Flags().Float64Var(&maxTotal, "max-credits-total", 0, "Don't spend more than this many credits in this run")
+	cmd.Flags().StringVar(&overBudget, "over-budget", overBudgetAbort, "What to do when a date would exceed a credit limit or the monthly budget: abort or skip")
+	cmd.Flags().StringVar(&planPath, "plan", "", "Book the entries of a YAML or CSV plan file instead of a single location and date selection")
+	cmd.Flags().BoolVar(&atomic, "atomic", false, "Book all dates at the same location or none: check every date first and cancel this run's bookings if one fails")
+	cmd.Flags().BoolVar(&waitApproval, "wait-for-approval", false, "After booking, wait until bookings that need approval are confirmed or rejected")
+	cmd.Flags().DurationVar(&approvalTimeout, "approval-timeout", 30*time.Minute, "How long --wait-for-approval waits")
+	cmd.Flags().BoolVar(&whenOpen, "when-open", false, "Wait until bookings open for each date, in the location's timezone, and book right then")
+	cmd.Flags().StringVar(&company, "company", "", "Company to book on behalf of, by UUID or name (default: your account's default)")
+	cmd.Flags().StringVar(&membership, "membership", "", "Membership to charge the booking to, by UUID or name (default: the company's preferred membership)")
+	cmd.Flags().StringVar(&quoteRef, "quote", "", "Book the space, hours and attendees of a quote saved by the quote command, by quote UUID or file; the quote is re-issued and must match the saved price")
+	cmd.Flags().Float64Var(&priceTolerance, "price-tolerance", 0, "With --quote, how many credits the re-issued quote's total may differ from the saved one")
+	cmd.Flags().StringArrayVar(&segmentValues, "segment", nil, "Book part of each date at a location, as \"LOCATION HH:MM-HH:MM\" (repeatable for a split day, e.g. morning and afternoon at different locations)")
+	cmd.Flags().BoolVar(&multiday, "multiday", false, "Book consecutive dates as one multi-day reservation where the location allows it, otherwise each day on its own")
+	cmd.Flags().IntVar(&horizon, "horizon", 0, "How many days ahead the location accepts bookings, for --when-open (default: detected)")
+	cmd.Flags().BoolVar(&verifyFlag, "verify", false, "Check that each booking shows up among upcoming bookings afterwards (default: on when booking several dates)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show spaces, hours and quoted credits for each date without booking")
 
 	return cmd
 }
@@ -0,0 +1,147 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/spinner"
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+// rollbackResult is the outcome of cancelling a booking made earlier in a failed atomic run.
+type rollbackResult struct {
+	Date        string `json:"date"`
+	BookingUUID string `json:"bookingUUID,omitempty"`
+	Cancelled   bool   `json:"cancelled"`
+	Error       string `json:"error,omitempty"`
+}
+
+// precheckDates checks availability and quotes for every date and returns the problems that
+// would make booking fail. Spending is projected on a copy of budget.
+func precheckDates(ww *wework.WeWork, jsonOut bool, sel spaceSelector, dates []time.Time, locationUUIDs []string, opts wework.BookingOptions, budget *creditBudget) []string {
+	projected := budget.clone()
+	var problems []string
+	for _, date := range dates {
+		plan := planBookingWithProgress(ww, jsonOut, sel, date, locationUUIDs, opts, projected)
+		if plan.Error != "" {
+			problems = append(problems, fmt.Sprintf("%s: %s", plan.Date, plan.Error))
+		}
+	}
+	return problems
+}
+
+// chooseAtomicLocation returns the first of locationUUIDs where every date can be booked, so an
+// atomic run never spreads over several locations. If none qualifies, the problems per location are returned.
+func chooseAtomicLocation(ww *wework.WeWork, jsonOut bool, sel spaceSelector, dates []time.Time, locationUUIDs []string, opts wework.BookingOptions, budget *creditBudget) (string, []string) {
+	var problems []string
+	for _, locationUUID := range locationUUIDs {
+		if !jsonOut && len(locationUUIDs) > 1 {
+			fmt.Printf("Checking all dates at %s…\n", locationUUID)
+		}
+		locationProblems := precheckDates(ww, jsonOut, sel, dates, []string{locationUUID}, opts, budget)
+		if len(locationProblems) == 0 {
+			return locationUUID, nil
+		}
+		for _, p := range locationProblems {
+			if len(locationUUIDs) > 1 {
+				p = locationUUID + ": " + p
+			}
+			problems = append(problems, p)
+		}
+	}
+	return "", problems
+}
+
+// rollbackBookings cancels the bookings that succeeded in results.
+func rollbackBookings(ww *wework.WeWork, jsonOut bool, results []bookResult) []rollbackResult {
+	var booked []bookResult
+	for _, res := range results {
+		if res.Error == "" && res.BookingStatus != nil {
+			booked = append(booked, res)
+		}
+	}
+	if len(booked) == 0 {
+		return nil
+	}
+
+	rollback := func(progress progressReporter) []rollbackResult {
+		progress.Update(fmt.Sprintf("Rolling back %d booking(s)…", len(booked)))
+		upcoming, err := ww.GetUpcomingBookings()
+
+		var rolledBack []rollbackResult
+		for _, res := range booked {
+			rb := rollbackResult{Date: res.Date}
+			switch booking := findBooking(upcoming, res); {
+			case err != nil:
+				rb.Error = fmt.Sprintf("failed to get upcoming bookings: %v", err)
+			case booking == nil:
+				rb.Error = "booking not found among upcoming bookings"
+			default:
+				rb.BookingUUID = booking.UUID
+				progress.Update(fmt.Sprintf("%s: cancelling booking %s…", res.Date, booking.UUID))
+				if _, err := ww.CancelBooking(booking); err != nil {
+					rb.Error = err.Error()
+				} else {
+					rb.Cancelled = true
+				}
+			}
+			rolledBack = append(rolledBack, rb)
+		}
+		return rolledBack
+	}
+
+	if jsonOut {
+		return rollback(quietProgress{})
+	}
+
+	// An incomplete rollback is shown by the spinner and returned per booking in rolledBack
+	var rolledBack []rollbackResult
+	_ = spinner.WithContinuousSpinner(func(cs *spinner.ContinuousSpinner) error {
+		rolledBack = rollback(cs)
+		failed := 0
+		for _, rb := range rolledBack {
+			if rb.Cancelled {
+				cs.Printf("   ↩ %s: cancelled booking %s\n", rb.Date, rb.BookingUUID)
+			} else {
+				cs.Printf("   ⚠️  %s: %s\n", rb.Date, rb.Error)
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("rollback incomplete: %d of %d booking(s) could not be cancelled, cancel them manually", failed, len(rolledBack))
+		}
+		cs.Success(fmt.Sprintf("Rolled back %d booking(s)", len(rolledBack)))
+		return nil
+	})
+	return rolledBack
+}
+
+// findBooking finds the booking created for res, by reservation ID or else by date and location.
+func findBooking(bookings []*wework.Booking, res bookResult) *wework.Booking {
+	if res.BookingStatus != nil && res.BookingStatus.ReservationID != "" {
+		for _, b := range bookings {
+			if b != nil && b.UUID == res.BookingStatus.ReservationID {
+				return b
+			}
+		}
+	}
+	for _, b := range bookings {
+		if b == nil || b.StartsAt.IsZero() || b.Reservable == nil || b.Reservable.Location == nil {
+			continue
+		}
+		if b.StartsAt.Time.Format("2006-01-02") == res.Date && b.Reservable.Location.UUID == res.LocationUUID {
+			return b
+		}
+	}
+	return nil
+}
+
+// rollbackFailed reports whether any booking could not be cancelled.
+func rollbackFailed(rolledBack []rollbackResult) bool {
+	for _, rb := range rolledBack {
+		if !rb.Cancelled {
+			return true
+		}
+	}
+	return false
+}
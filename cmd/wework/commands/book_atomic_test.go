@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+func TestFindBooking(t *testing.T) {
+	at := func(day int) wework.CustomTime {
+		return wework.CustomTime{Time: time.Date(2024, 6, day, 9, 0, 0, 0, time.UTC)}
+	}
+	location := func(uuid string) *wework.SharedWorkspace {
+		return &wework.SharedWorkspace{Location: &wework.SharedWorkspaceLocation{UUID: uuid}}
+	}
+	bookings := []*wework.Booking{
+		{UUID: "booking1", StartsAt: at(3), Reservable: location("loc1")},
+		{UUID: "booking2", StartsAt: at(4), Reservable: location("loc1")},
+		{UUID: "booking3", StartsAt: at(4), Reservable: location("loc2")},
+	}
+
+	tests := []struct {
+		name     string
+		res      bookResult
+		expected string
+	}{
+		{
+			name:     "by reservation id",
+			res:      bookResult{Date: "2024-06-04", LocationUUID: "loc1", BookingStatus: &wework.BookingResponse{ReservationID: "booking3"}},
+			expected: "booking3",
+		},
+		{
+			name:     "by date and location",
+			res:      bookResult{Date: "2024-06-04", LocationUUID: "loc2", BookingStatus: &wework.BookingResponse{ReservationID: "unknown"}},
+			expected: "booking3",
+		},
+		{
+			name: "not found",
+			res:  bookResult{Date: "2024-06-05", LocationUUID: "loc1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findBooking(bookings, tt.res)
+			switch {
+			case tt.expected == "" && got != nil:
+				t.Errorf("expected no booking, got %s", got.UUID)
+			case tt.expected != "" && got == nil:
+				t.Errorf("expected booking %s, got none", tt.expected)
+			case got != nil && got.UUID != tt.expected:
+				t.Errorf("expected booking %s, got %s", tt.expected, got.UUID)
+			}
+		})
+	}
+}
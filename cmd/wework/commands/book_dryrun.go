@@ -0,0 +1,193 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/spinner"
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+// plannedBooking describes what `book` would do for a single date without creating the booking.
+type plannedBooking struct {
+	Date               string         `json:"date"`
+	Fallbacks          []string       `json:"fallbacks,omitempty"`
+	SpaceUUID          string         `json:"spaceUUID,omitempty"`
+	LocationUUID       string         `json:"locationUUID,omitempty"`
+	LocationName       string         `json:"locationName,omitempty"`
+	StartTime          string         `json:"startTime,omitempty"`
+	EndTime            string         `json:"endTime,omitempty"`
+	TimeZone           string         `json:"timeZone,omitempty"`
+	LocalTime          string         `json:"localTime,omitempty"`
+	QuoteUUID          string         `json:"quoteUUID,omitempty"`
+	Seats              int            `json:"seats"`
+	Guests             []wework.Guest `json:"guests,omitempty"`
+	Note               string         `json:"note,omitempty"`
+	Credits            float64        `json:"credits"`
+	OverBudget         bool           `json:"overBudget,omitempty"`
+	CancellationPolicy string         `json:"cancellationPolicy,omitempty"`
+	Error              string         `json:"error,omitempty"`
+	ErrorCode          string         `json:"errorCode,omitempty"`
+	Hint               string         `json:"hint,omitempty"`
+}
+
+// planBooking resolves the space and quote for a date, i.e. everything `book` does except creating the booking.
+func planBooking(ww *wework.WeWork, progress progressReporter, sel spaceSelector, date time.Time, locationUUIDs []string, opts wework.BookingOptions, budget *creditBudget) plannedBooking {
+	dateStr := date.Format("2006-01-02")
+	plan := plannedBooking{Date: dateStr, Seats: opts.SeatCount(), Guests: opts.Guests}
+
+	space, passed, err := findSpaceInOrder(ww, progress, sel, date, locationUUIDs)
+	plan.Fallbacks = passed
+	if err != nil {
+		plan.Error = err.Error()
+		return plan
+	}
+	plan.SpaceUUID = space.UUID
+	plan.LocationUUID = space.Location.UUID
+	plan.LocationName = space.Location.Name
+	plan.TimeZone = space.Location.TimeZone
+	plan.CancellationPolicy = space.CancellationPolicy
+	opts.Note = renderNote(opts.Note, date, space)
+	plan.Note = opts.Note
+
+	start, end, err := opts.Window(date, space)
+	if err != nil {
+		plan.Error = fmt.Sprintf("invalid booking window: %v", err)
+		return plan
+	}
+	plan.StartTime = start.Format("15:04")
+	plan.EndTime = end.Format("15:04 MST")
+	plan.LocalTime = localWindow(start, end)
+
+	progress.Update(fmt.Sprintf("%s: fetching quote for %s…", dateStr, space.Location.Name))
+	quote, err := ww.GetBookingQuoteWithOptions(date, space, opts)
+	if err != nil {
+		plan.Error = fmt.Sprintf("failed to get booking quote: %v", err)
+		berr := wework.ClassifyBookingError(err.Error())
+		plan.ErrorCode = berr.Code
+		plan.Hint = berr.Hint
+		return plan
+	}
+	plan.QuoteUUID = quote.UUID
+	plan.Credits = quote.GrandTotal.Amount
+	if err := budget.check(date, plan.Credits); err != nil {
+		plan.Error = err.Error()
+		plan.OverBudget = true
+		return plan
+	}
+	budget.commit(date, plan.Credits)
+
+	return plan
+}
+
+// planBookingWithProgress runs planBooking, showing a spinner and the outcome in text mode.
+func planBookingWithProgress(ww *wework.WeWork, jsonOut bool, sel spaceSelector, date time.Time, locationUUIDs []string, opts wework.BookingOptions, budget *creditBudget) plannedBooking {
+	if jsonOut {
+		return planBooking(ww, quietProgress{}, sel, date, locationUUIDs, opts, budget)
+	}
+
+	// A failed plan is shown by the spinner and kept in plan.Error
+	var plan plannedBooking
+	_ = spinner.WithContinuousSpinner(func(cs *spinner.ContinuousSpinner) error {
+		plan = planBooking(ww, cs, sel, date, locationUUIDs, opts, budget)
+		if plan.Error != "" {
+			return fmt.Errorf("%s: %s", plan.Date, plan.Error)
+		}
+		cs.Success(fmt.Sprintf("%s: quoted %.2f credits", plan.Date, plan.Credits))
+		return nil
+	})
+	return plan
+}
+
+// summarizePlans counts plans like summarize counts results: a date that was quoted counts as
+// booked, so a dry run exits with the status the real run would.
+func summarizePlans(plans []plannedBooking, skipped []skippedDate) batchSummary {
+	s := batchSummary{Skipped: len(skipped)}
+	for _, plan := range plans {
+		if plan.Error != "" {
+			s.Failed++
+		} else {
+			s.Booked++
+		}
+	}
+	return s
+}
+
+// runBookDryRun prints the booking plan for all dates and the total credit cost. It returns an
+// ExitPartial or ExitAllFailed error when dates can't be booked, like booking them would.
+func runBookDryRun(ww *wework.WeWork, jsonOut bool, sel spaceSelector, dates []time.Time, locationUUIDs []string, skipped []skippedDate, opts wework.BookingOptions, budget *creditBudget) error {
+	var plans []plannedBooking
+	for _, date := range dates {
+		plans = append(plans, planBookingWithProgress(ww, jsonOut, sel, date, locationUUIDs, opts, budget))
+	}
+	total := plannedCredits(plans)
+
+	if jsonOut {
+		payload := map[string]any{"dryRun": true, "plan": plans, "skipped": skipped, "totalCredits": total}
+		if budget.limited() {
+			payload["budget"] = budget.report(dates)
+		}
+		b, err := json.MarshalIndent(payload, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %v", err)
+		}
+		fmt.Println(string(b))
+		return summarizePlans(plans, skipped).err()
+	}
+
+	fmt.Println("\nDry run — no bookings will be created:")
+	if attendees := formatAttendees(opts.SeatCount(), opts.Guests); attendees != "" {
+		fmt.Printf("Booking for %s\n", attendees)
+	}
+	printBookingPlan(plans)
+	fmt.Printf("Total: %.2f credits\n", total)
+	if budget.limited() {
+		fmt.Println(budget.report(dates).format("Projected"))
+	}
+	return summarizePlans(plans, skipped).err()
+}
+
+// plannedCredits sums the quoted credits of all plans that can be booked.
+func plannedCredits(plans []plannedBooking) float64 {
+	var total float64
+	for _, plan := range plans {
+		if plan.Error == "" {
+			total += plan.Credits
+		}
+	}
+	return total
+}
+
+// printBookingPlan prints plans as a table.
+func printBookingPlan(plans []plannedBooking) {
+	fmt.Printf("%-16s%-30s%-22s%-10s%s\n", "Date", "Space", "Hours", "Credits", "Cancellation Policy")
+	fmt.Println(strings.Repeat("-", 110))
+	for _, plan := range plans {
+		if plan.Error != "" {
+			fmt.Printf("%-16s❌ %s\n", plan.Date, plan.Error)
+			if plan.Hint != "" {
+				fmt.Printf("%-16s   → %s\n", "", plan.Hint)
+			}
+			continue
+		}
+		name := plan.LocationName
+		if len(name) > 28 {
+			name = name[:28]
+		}
+		fmt.Printf("%-16s%-30s%-22s%-10.2f%s\n",
+			plan.Date,
+			name,
+			fmt.Sprintf("%s ~ %s", plan.StartTime, plan.EndTime),
+			plan.Credits,
+			plan.CancellationPolicy)
+		if plan.LocalTime != "" {
+			fmt.Printf("%-16sLocal time: %s\n", "", plan.LocalTime)
+		}
+		if plan.Note != "" {
+			fmt.Printf("%-16sNote: %s\n", "", plan.Note)
+		}
+	}
+	fmt.Println(strings.Repeat("-", 110))
+}
@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/spinner"
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+// spanDays returns the calendar days from date through end, or just date when end isn't after it.
+func spanDays(date, end time.Time) []time.Time {
+	days := []time.Time{date}
+	last := end.Format("2006-01-02")
+	for d := date.AddDate(0, 0, 1); d.Format("2006-01-02") <= last; d = d.AddDate(0, 0, 1) {
+		days = append(days, d)
+	}
+	return days
+}
+
+// consecutiveRuns splits sorted dates into runs of consecutive calendar days.
+func consecutiveRuns(dates []time.Time) [][]time.Time {
+	var runs [][]time.Time
+	for i, d := range dates {
+		if i > 0 && dates[i-1].AddDate(0, 0, 1).Format("2006-01-02") == d.Format("2006-01-02") {
+			runs[len(runs)-1] = append(runs[len(runs)-1], d)
+			continue
+		}
+		runs = append(runs, []time.Time{d})
+	}
+	return runs
+}
+
+// days returns how many dates the result covers.
+func (res bookResult) days() int {
+	if res.EndDate == "" {
+		return 1
+	}
+	start, err1 := time.Parse("2006-01-02", res.Date)
+	end, err2 := time.Parse("2006-01-02", res.EndDate)
+	if err1 != nil || err2 != nil {
+		return 1
+	}
+	return len(spanDays(start, end))
+}
+
+// dateRange formats the date, or the dates of a multi-day booking.
+func (res bookResult) dateRange() string {
+	if res.EndDate == "" {
+		return res.Date
+	}
+	return fmt.Sprintf("%s ~ %s", res.Date, res.EndDate)
+}
+
+// multidaySpace finds a space at the first of locationUUIDs that has a seat on the first day, and
+// returns it with a selector pinned to it if it has a seat on every other day as well.
+func multidaySpace(ww spaceLister, progress progressReporter, sel spaceSelector, days []time.Time, locationUUIDs []string) (*wework.Workspace, spaceSelector, bool) {
+	space, _, err := findSpaceInOrder(ww, progress, sel, days[0], locationUUIDs)
+	if err != nil || !workspaceFits(space, sel.seatCount()) {
+		return nil, sel, false
+	}
+
+	// The same space must have a seat on every day
+	pinned := sel
+	pinned.SpaceUUID = space.UUID
+	pinned.Interactive = false
+	for _, d := range days[1:] {
+		day, err := findSpace(ww, progress, pinned, d, space.Location.UUID)
+		if err != nil || !workspaceFits(day, sel.seatCount()) {
+			return nil, sel, false
+		}
+	}
+	return space, pinned, true
+}
+
+// bookMultiday books days as one multi-day reservation at the first of locationUUIDs that has a
+// seat on all of them. It returns false, having booked nothing, when no location has a seat on
+// every day or the multi-day quote or booking is rejected, so the caller can book per day instead.
+func bookMultiday(ww *wework.WeWork, progress progressReporter, sel spaceSelector, days []time.Time, locationUUIDs []string, replace []*wework.Booking, opts wework.BookingOptions, budget *creditBudget) (bookResult, bool) {
+	first, last := days[0], days[len(days)-1]
+	space, pinned, ok := multidaySpace(ww, progress, sel, days, locationUUIDs)
+	if !ok {
+		return bookResult{}, false
+	}
+
+	opts.EndDate = last
+	progress.Update(fmt.Sprintf("%s: booking %d days as one reservation…", first.Format("2006-01-02"), len(days)))
+	res := bookDate(ww, progress, pinned, first, []string{space.Location.UUID}, replace, opts, budget)
+	res.EndDate = last.Format("2006-01-02")
+	if res.Error != "" && !res.OverBudget && len(res.Replaced) == 0 {
+		// Nothing changed; the location likely doesn't take multi-day bookings
+		return res, false
+	}
+	return res, true
+}
+
+// bookMultidayWithProgress runs bookMultiday, showing a spinner and the outcome in text mode.
+func bookMultidayWithProgress(ww *wework.WeWork, jsonOut bool, sel spaceSelector, days []time.Time, locationUUIDs []string, replace []*wework.Booking, opts wework.BookingOptions, budget *creditBudget) (bookResult, bool) {
+	if jsonOut {
+		return bookMultiday(ww, quietProgress{}, sel, days, locationUUIDs, replace, opts, budget)
+	}
+
+	var res bookResult
+	var ok bool
+	err := spinner.WithContinuousSpinner(func(cs *spinner.ContinuousSpinner) error {
+		res, ok = bookMultiday(ww, cs, sel, days, locationUUIDs, replace, opts, budget)
+		if !ok {
+			cs.Success(fmt.Sprintf("%s ~ %s can't be booked as one reservation, booking each day instead",
+				days[0].Format("2006-01-02"), days[len(days)-1].Format("2006-01-02")))
+			return nil
+		}
+		return reportBookResult(cs, res)
+	})
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	return res, ok
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+func TestConsecutiveRuns(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name     string
+		dates    []time.Time
+		expected string
+	}{
+		{name: "single date", dates: []time.Time{day(2)}, expected: "02"},
+		{name: "one run", dates: []time.Time{day(2), day(3), day(4)}, expected: "02,03,04"},
+		{name: "gap", dates: []time.Time{day(2), day(3), day(5), day(6)}, expected: "02,03 05,06"},
+		{name: "month end", dates: []time.Time{time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), day(1)}, expected: "28,01"},
+		{name: "no dates", expected: ""},
+	}
+
+	for _, tt := range tests {
+		var runs []string
+		for _, run := range consecutiveRuns(tt.dates) {
+			var days []string
+			for _, d := range run {
+				days = append(days, d.Format("02"))
+			}
+			runs = append(runs, strings.Join(days, ","))
+		}
+		if got := strings.Join(runs, " "); got != tt.expected {
+			t.Errorf("%s: consecutiveRuns() = %q, want %q", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestBookResultDays(t *testing.T) {
+	tests := []struct {
+		res      bookResult
+		expected int
+	}{
+		{res: bookResult{Date: "2026-03-02"}, expected: 1},
+		{res: bookResult{Date: "2026-03-02", EndDate: "2026-03-02"}, expected: 1},
+		{res: bookResult{Date: "2026-03-02", EndDate: "2026-03-06"}, expected: 5},
+		{res: bookResult{Date: "2026-02-27", EndDate: "2026-03-02"}, expected: 4},
+	}
+
+	for _, tt := range tests {
+		if got := tt.res.days(); got != tt.expected {
+			t.Errorf("days() for %s = %d, want %d", tt.res.dateRange(), got, tt.expected)
+		}
+	}
+}
+
+// datedSpaces serves the same workspace with the seats available per date.
+type datedSpaces map[string]int
+
+func (f datedSpaces) GetAvailableSpaces(t time.Time, locationUUIDs []string) (*wework.SharedWorkspaceResponse, error) {
+	res := &wework.SharedWorkspaceResponse{}
+	res.Response.Workspaces = []wework.Workspace{{
+		UUID:     "space-1",
+		Seat:     wework.Seat{Available: f[t.Format("2006-01-02")]},
+		Location: wework.Location{UUID: locationUUIDs[0]},
+	}}
+	return res, nil
+}
+
+func TestMultidaySpace(t *testing.T) {
+	days := []time.Time{
+		time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name     string
+		seats    datedSpaces
+		group    int
+		expected bool
+	}{
+		{name: "seat every day", seats: datedSpaces{"2026-03-16": 1, "2026-03-17": 2, "2026-03-18": 1}, expected: true},
+		{name: "full on the first day", seats: datedSpaces{"2026-03-17": 2, "2026-03-18": 1}},
+		{name: "full on a later day", seats: datedSpaces{"2026-03-16": 1, "2026-03-18": 1}},
+		{name: "too few seats for a group on a later day", seats: datedSpaces{"2026-03-16": 3, "2026-03-17": 3, "2026-03-18": 2}, group: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			space, pinned, ok := multidaySpace(tt.seats, quietProgress{}, spaceSelector{Seats: tt.group}, days, []string{"loc-1"})
+			if ok != tt.expected {
+				t.Fatalf("expected %v, got %v", tt.expected, ok)
+			}
+			if ok && (space.UUID != "space-1" || pinned.SpaceUUID != "space-1") {
+				t.Errorf("expected space-1 pinned, got %s pinned to %q", space.UUID, pinned.SpaceUUID)
+			}
+		})
+	}
+}
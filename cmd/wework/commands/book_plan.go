@@ -0,0 +1,570 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/config"
+	"github.com/dvcrn/wework-cli/pkg/wework"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// planEntry is one entry of a booking plan file. Dates and recurrence work like the
+// corresponding book flags; location accepts a UUID, a comma-separated list or a config alias.
+type planEntry struct {
+	Date         string   `yaml:"date"`
+	Every        string   `yaml:"every"`
+	Interval     int      `yaml:"interval"`
+	Until        string   `yaml:"until"`
+	Count        int      `yaml:"count"`
+	RRule        string   `yaml:"rrule"`
+	WeekdaysOnly bool     `yaml:"weekdaysOnly"`
+	Location     string   `yaml:"location"`
+	City         string   `yaml:"city"`
+	Name         string   `yaml:"name"`
+	Space        string   `yaml:"space"`
+	Time         string   `yaml:"time"`
+	Note         string   `yaml:"note"`
+	Profile      string   `yaml:"profile"`
+	Seats        int      `yaml:"seats"`
+	Guests       []string `yaml:"guests"`
+}
+
+func (e planEntry) label() string {
+	dates := e.Date
+	if e.Every != "" {
+		dates = fmt.Sprintf("every %s from %s", e.Every, e.Date)
+	} else if e.RRule != "" {
+		dates = fmt.Sprintf("%s from %s", e.RRule, e.Date)
+	}
+	location := e.Location
+	if location == "" {
+		location = fmt.Sprintf("%s, %s", e.Name, e.City)
+	}
+	label := fmt.Sprintf("%s at %s", dates, location)
+	if e.Time != "" {
+		label += " " + e.Time
+	}
+	return label
+}
+
+// planEntryFlags are the book flags that plan entries set per entry.
+var planEntryFlags = []string{
+	"location-uuid", "city", "name", "seats", "guest", "note",
+	"date", "every", "interval", "until", "count", "rrule",
+}
+
+// checkPlanFlags rejects flags that a plan would silently ignore.
+func checkPlanFlags(cmd *cobra.Command) error {
+	for _, name := range planEntryFlags {
+		if cmd.Flags().Changed(name) {
+			return fmt.Errorf("--plan cannot be combined with --%s, set it per entry in the plan file", name)
+		}
+	}
+	return nil
+}
+
+// loadPlan reads plan entries from a YAML (.yaml, .yml) or CSV (.csv) file.
+func loadPlan(path string) ([]planEntry, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan: %v", err)
+	}
+
+	var entries []planEntry
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		entries, err = parsePlanYAML(b)
+	case ".csv":
+		entries, err = parsePlanCSV(b)
+	default:
+		return nil, fmt.Errorf("unsupported plan format '%s' (expected .yaml, .yml or .csv)", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse plan %s: %v", path, err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("plan %s has no entries", path)
+	}
+	return entries, nil
+}
+
+// parsePlanYAML accepts either a list of entries or a document with an "entries" list.
+func parsePlanYAML(b []byte) ([]planEntry, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return nil, err
+	}
+	if len(node.Content) == 0 {
+		return nil, nil
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	if node.Content[0].Kind == yaml.SequenceNode {
+		var entries []planEntry
+		err := dec.Decode(&entries)
+		return entries, err
+	}
+	var file struct {
+		Entries []planEntry `yaml:"entries"`
+	}
+	err := dec.Decode(&file)
+	return file.Entries, err
+}
+
+// parsePlanCSV reads a CSV file whose header names the entry fields, e.g. date,location,time,note.
+// Multiple guests are separated by semicolons.
+func parsePlanCSV(b []byte) ([]planEntry, error) {
+	r := csv.NewReader(bytes.NewReader(b))
+	r.Comment = '#'
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	var entries []planEntry
+	for i, record := range records[1:] {
+		var e planEntry
+		for j, column := range header {
+			value := strings.TrimSpace(record[j])
+			if value == "" {
+				continue
+			}
+			if err := e.set(strings.TrimSpace(column), value); err != nil {
+				return nil, fmt.Errorf("line %d: %v", i+2, err)
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func (e *planEntry) set(field, value string) error {
+	var err error
+	switch strings.ToLower(field) {
+	case "date":
+		e.Date = value
+	case "every":
+		e.Every = value
+	case "interval":
+		e.Interval, err = strconv.Atoi(value)
+	case "until":
+		e.Until = value
+	case "count":
+		e.Count, err = strconv.Atoi(value)
+	case "rrule":
+		e.RRule = value
+	case "weekdaysonly":
+		e.WeekdaysOnly, err = strconv.ParseBool(value)
+	case "location":
+		e.Location = value
+	case "city":
+		e.City = value
+	case "name":
+		e.Name = value
+	case "space":
+		e.Space = value
+	case "time":
+		e.Time = value
+	case "note":
+		e.Note = value
+	case "profile":
+		e.Profile = value
+	case "seats":
+		e.Seats, err = strconv.Atoi(value)
+	case "guests":
+		for _, g := range strings.Split(value, ";") {
+			if g = strings.TrimSpace(g); g != "" {
+				e.Guests = append(e.Guests, g)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown column '%s'", field)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s '%s'", field, value)
+	}
+	return nil
+}
+
+// parseTimeRange splits "09:00-13:00" into its start and end, zero-padded as "15:04" so they
+// compare correctly as strings ("9:00" becomes "09:00").
+func parseTimeRange(s string) (string, string, error) {
+	startStr, endStr, ok := strings.Cut(s, "-")
+	if !ok {
+		return "", "", fmt.Errorf("invalid time '%s' (expected HH:MM-HH:MM)", s)
+	}
+	start, err := time.Parse("15:04", strings.TrimSpace(startStr))
+	if err != nil {
+		return "", "", fmt.Errorf("invalid time '%s' (expected HH:MM-HH:MM)", s)
+	}
+	end, err := time.Parse("15:04", strings.TrimSpace(endStr))
+	if err != nil {
+		return "", "", fmt.Errorf("invalid time '%s' (expected HH:MM-HH:MM)", s)
+	}
+	if !end.After(start) {
+		return "", "", fmt.Errorf("invalid time '%s': end must be after start", s)
+	}
+	return start.Format("15:04"), end.Format("15:04"), nil
+}
+
+// preparedEntry is a validated plan entry with everything needed to book it.
+type preparedEntry struct {
+	index         int
+	label         string
+	locationUUIDs []string
+	sel           spaceSelector
+	opts          wework.BookingOptions
+	dates         []time.Time
+	skipped       []skippedDate
+}
+
+// planEntryResult is the per-entry report of a plan run.
+type planEntryResult struct {
+	Entry   int              `json:"entry"`
+	Label   string           `json:"label"`
+	Plan    []plannedBooking `json:"plan,omitempty"`
+	Results []bookResult     `json:"results,omitempty"`
+	Skipped []skippedDate    `json:"skipped,omitempty"`
+}
+
+// planRunner books the entries of a plan file with the settings of the book command.
+type planRunner struct {
+	ww         *wework.WeWork
+	cmd        *cobra.Command
+	cfg        *config.Config
+	sel        spaceSelector
+	exclusions dateOptions
+	onConflict string
+	overBudget string
+	budget     *creditBudget
+	dryRun     bool
+	atomic     bool
+	jsonOut    bool
+	payer      wework.Payer
+
+	waitApproval    bool
+	approvalTimeout time.Duration
+	verify          *bool
+
+	rolledBack []rollbackResult
+	// warnings are problems that don't fail the run, reported in JSON mode
+	warnings []string
+}
+
+// warn reports a problem that doesn't fail the run: printed in text mode, collected for the payload in JSON mode.
+func (r *planRunner) warn(warning string) {
+	if r.jsonOut {
+		r.warnings = append(r.warnings, warning)
+		return
+	}
+	fmt.Printf("⚠️  %s\n", warning)
+}
+
+// prepare validates an entry and resolves its locations, dates and booking options.
+func (r *planRunner) prepare(index int, e planEntry) (*preparedEntry, error) {
+	p := &preparedEntry{index: index, label: e.label(), sel: r.sel}
+
+	if e.Date == "" {
+		return nil, fmt.Errorf("date is required")
+	}
+	if e.Location == "" && (e.Name == "" || e.City == "") {
+		return nil, fmt.Errorf("location OR (city + name) is required")
+	}
+
+	locationUUIDs, err := resolveLocationUUIDs(r.ww, r.cfg, e.City, e.Name, e.Location)
+	if err != nil {
+		return nil, err
+	}
+	p.locationUUIDs = locationUUIDs
+
+	interval := e.Interval
+	if interval == 0 {
+		interval = 1
+	}
+	dateOpts := dateOptions{
+		Date:         e.Date,
+		Every:        e.Every,
+		Interval:     interval,
+		Until:        e.Until,
+		Count:        e.Count,
+		RRule:        e.RRule,
+		TZ:           r.exclusions.TZ,
+		WeekdaysOnly: e.WeekdaysOnly || r.exclusions.WeekdaysOnly,
+		Holidays:     r.exclusions.Holidays,
+	}
+	dates, err := dateOpts.resolveAt(r.ww, locationUUIDs)
+	if err != nil {
+		return nil, err
+	}
+	// Closed days are a convenience; if opening hours can't be fetched the quote will tell
+	closed, _ := closedWeekdays(r.ww, locationUUIDs)
+	if p.dates, p.skipped, err = dateOpts.exclude(dates, closed); err != nil {
+		return nil, err
+	}
+
+	var profile config.Profile
+	if e.Profile != "" {
+		profile, err = r.cfg.Profile(e.Profile)
+	} else {
+		profile, err = loadProfile(r.cmd, r.cfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if p.opts, err = bookingOptions(e.Seats, e.Guests); err != nil {
+		return nil, err
+	}
+	p.opts.Note = noteTemplate(e.Note, profile)
+	p.opts = withPayer(p.opts, r.payer)
+	if e.Time != "" {
+		if p.opts.StartTime, p.opts.EndTime, err = parseTimeRange(e.Time); err != nil {
+			return nil, err
+		}
+	}
+
+	if e.Space != "" {
+		p.sel.SpaceUUID = e.Space
+	}
+	p.sel.Seats = p.opts.Seats
+	return p, nil
+}
+
+// run validates the whole plan, including a quote for every date, and only then books it.
+func (r *planRunner) run(path string) error {
+	entries, err := loadPlan(path)
+	if err != nil {
+		return usageError(err)
+	}
+
+	// Validate every entry before touching anything
+	var prepared []*preparedEntry
+	var problems []string
+	plannedBy := make(map[string]int)
+	for i, e := range entries {
+		p, err := r.prepare(i+1, e)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("entry %d (%s): %v", i+1, e.label(), err))
+			continue
+		}
+		for _, d := range p.dates {
+			dateStr := d.Format("2006-01-02")
+			if other, ok := plannedBy[dateStr]; ok {
+				problems = append(problems, fmt.Sprintf("entry %d (%s): %s is already planned by entry %d", i+1, p.label, dateStr, other))
+				continue
+			}
+			plannedBy[dateStr] = i + 1
+		}
+		prepared = append(prepared, p)
+	}
+	if len(problems) > 0 {
+		return usageError(fmt.Errorf("invalid plan %s:\n  %s", path, strings.Join(problems, "\n  ")))
+	}
+
+	// Dates that are already booked make re-applying a plan a no-op
+	upcoming, err := loadUpcomingBookings(r.ww, r.jsonOut)
+	if err != nil {
+		return err
+	}
+	if err := r.budget.loadUsage(r.ww, upcoming); err != nil {
+		return err
+	}
+	existing := bookingsByDate(upcoming)
+	for _, p := range prepared {
+		free, conflicts := splitConflicts(p.dates, existing)
+		switch r.onConflict {
+		case onConflictSkip:
+			p.dates = free
+			p.skipped = append(p.skipped, conflicts...)
+		case onConflictFail:
+			if len(conflicts) > 0 {
+				problems = append(problems, fmt.Sprintf("entry %d (%s): dates already booked: %s", p.index, p.label, formatSkipped(conflicts)))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return usageError(fmt.Errorf("invalid plan %s:\n  %s", path, strings.Join(problems, "\n  ")))
+	}
+
+	// Quote every date, projecting spending on a copy of the budget where replaced bookings no longer count
+	projected := r.budget.clone()
+	if r.onConflict == onConflictReplace {
+		for _, p := range prepared {
+			projected.release(replacementsFor(existing, p.dates, nil))
+		}
+	}
+	reports := make([]planEntryResult, len(prepared))
+	var allDates []time.Time
+	for i, p := range prepared {
+		reports[i] = planEntryResult{Entry: p.index, Label: p.label, Skipped: p.skipped}
+		if !r.jsonOut {
+			fmt.Printf("\nEntry %d: %s\n", p.index, p.label)
+			for _, s := range p.skipped {
+				fmt.Printf("⏭  %s: %s, skipping\n", s.Date, s.Reason)
+			}
+		}
+		for _, d := range p.dates {
+			plan := planBookingWithProgress(r.ww, r.jsonOut, p.sel, d, p.locationUUIDs, p.opts, projected)
+			if plan.Error != "" {
+				problems = append(problems, fmt.Sprintf("entry %d (%s): %s: %s", p.index, p.label, plan.Date, plan.Error))
+			}
+			reports[i].Plan = append(reports[i].Plan, plan)
+			allDates = append(allDates, d)
+		}
+	}
+
+	if r.dryRun || len(problems) > 0 {
+		if err := r.print(path, reports, projected, allDates); err != nil {
+			return err
+		}
+		if len(problems) > 0 {
+			return &ExitError{Code: ExitAllFailed, Err: fmt.Errorf("plan %s cannot be booked, nothing was booked:\n  %s", path, strings.Join(problems, "\n  "))}
+		}
+		return nil
+	}
+
+	// Book it
+	var all []bookResult
+	var lastOverBudget, atomicFailed bool
+	for i, p := range prepared {
+		reports[i].Plan = nil
+		if !r.jsonOut && !atomicFailed {
+			fmt.Printf("\nBooking entry %d: %s\n", p.index, p.label)
+		}
+		for _, d := range p.dates {
+			switch {
+			case atomicFailed:
+				reports[i].Skipped = append(reports[i].Skipped, skippedDate{Date: d.Format("2006-01-02"), Reason: "not attempted, atomic booking failed"})
+				continue
+			case lastOverBudget && r.overBudget == overBudgetAbort:
+				reports[i].Skipped = append(reports[i].Skipped, skippedDate{Date: d.Format("2006-01-02"), Reason: "not attempted, budget exceeded"})
+				continue
+			}
+			var replace []*wework.Booking
+			if r.onConflict == onConflictReplace {
+				replace = replacementsFor(existing, []time.Time{d}, all)
+			}
+			res := bookDateWithProgress(r.ww, r.jsonOut, p.sel, d, p.locationUUIDs, replace, p.opts, r.budget)
+			lastOverBudget = res.OverBudget
+			reports[i].Results = append(reports[i].Results, res)
+			all = append(all, res)
+			if r.atomic && res.Error != "" {
+				atomicFailed = true
+				r.rolledBack = rollbackBookings(r.ww, r.jsonOut, all)
+			}
+		}
+	}
+
+	var accepted []*bookResult
+	for i := range reports {
+		for j := range reports[i].Results {
+			accepted = append(accepted, &reports[i].Results[j])
+		}
+	}
+	if !atomicFailed && shouldVerify(r.verify, len(allDates)) {
+		if err := verifyBookings(r.ww, r.jsonOut, accepted); err != nil {
+			r.warn(verifyWarning(err))
+		}
+	}
+	if r.waitApproval && !atomicFailed {
+		if err := waitForApproval(r.ww, r.jsonOut, accepted, r.approvalTimeout); err != nil {
+			r.warn(approvalWarning(err))
+		}
+	}
+
+	if err := r.print(path, reports, r.budget, allDates); err != nil {
+		return err
+	}
+	if atomicFailed {
+		if rollbackFailed(r.rolledBack) {
+			return &ExitError{Code: ExitPartial, Err: fmt.Errorf("atomic plan failed and the rollback is incomplete, check your bookings")}
+		}
+		return &ExitError{Code: ExitAllFailed, Err: fmt.Errorf("atomic plan failed, rolled back %d booking(s)", len(r.rolledBack))}
+	}
+	return r.summary(reports).err()
+}
+
+// summary counts the booking results and skipped dates of all entries.
+func (r *planRunner) summary(reports []planEntryResult) batchSummary {
+	var results []bookResult
+	var skipped []skippedDate
+	for _, report := range reports {
+		results = append(results, report.Results...)
+		skipped = append(skipped, report.Skipped...)
+	}
+	summary := summarize(results, skipped)
+	summary.rollBack(r.rolledBack)
+	return summary
+}
+
+// print writes the per-entry report: the quoted plan for dry runs and failed validation,
+// the booking results otherwise.
+func (r *planRunner) print(path string, reports []planEntryResult, budget *creditBudget, dates []time.Time) error {
+	var planned bool
+	for _, report := range reports {
+		planned = planned || report.Plan != nil
+	}
+
+	if r.jsonOut {
+		payload := map[string]any{"plan": path, "dryRun": r.dryRun, "entries": reports}
+		if !r.dryRun && !planned {
+			payload["summary"] = r.summary(reports)
+		}
+		if budget.limited() {
+			payload["budget"] = budget.report(dates)
+		}
+		if r.atomic {
+			payload["rolledBack"] = r.rolledBack
+		}
+		if len(r.warnings) > 0 {
+			payload["warnings"] = r.warnings
+		}
+		b, err := json.MarshalIndent(payload, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %v", err)
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	var total float64
+	for _, report := range reports {
+		if report.Plan != nil {
+			fmt.Printf("\nEntry %d: %s\n", report.Entry, report.Label)
+			printBookingPlan(report.Plan)
+			total += plannedCredits(report.Plan)
+		}
+	}
+
+	if r.dryRun {
+		fmt.Printf("\nDry run — no bookings will be created. Total: %.2f credits\n", total)
+		if budget.limited() {
+			fmt.Println(budget.report(dates).format("Projected"))
+		}
+		return nil
+	}
+	if planned {
+		// Validation failed; the caller reports the problems
+		return nil
+	}
+	fmt.Printf("\nPlan %s: %s\n", path, r.summary(reports))
+	if budget.limited() {
+		fmt.Println(budget.report(dates).format("Spent"))
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePlan(t *testing.T) {
+	expected := []planEntry{
+		{Date: "2024-06-03", Location: "office", Time: "09:00-13:00", Note: "ACME-42"},
+		{Date: "2024-06-04", Every: "tue,thu", Until: "2024-06-30", City: "Tokyo", Name: "Shibuya", Guests: []string{"Jane", "bob@example.com"}},
+	}
+
+	tests := []struct {
+		name          string
+		parse         func([]byte) ([]planEntry, error)
+		input         string
+		errorContains string
+	}{
+		{
+			name:  "yaml entries",
+			parse: parsePlanYAML,
+			input: `entries:
+  - date: 2024-06-03
+    location: office
+    time: 09:00-13:00
+    note: ACME-42
+  - date: 2024-06-04
+    every: tue,thu
+    until: 2024-06-30
+    city: Tokyo
+    name: Shibuya
+    guests: [Jane, bob@example.com]
+`,
+		},
+		{
+			name:  "yaml list",
+			parse: parsePlanYAML,
+			input: `- {date: 2024-06-03, location: office, time: 09:00-13:00, note: ACME-42}
+- {date: 2024-06-04, every: "tue,thu", until: 2024-06-30, city: Tokyo, name: Shibuya, guests: [Jane, bob@example.com]}
+`,
+		},
+		{
+			name:          "yaml unknown field",
+			parse:         parsePlanYAML,
+			input:         "- date: 2024-06-03\n  loction: office\n",
+			errorContains: "loction",
+		},
+		{
+			name:  "csv",
+			parse: parsePlanCSV,
+			input: `date,location,city,name,every,until,time,note,guests
+# comment
+2024-06-03,office,,,,,09:00-13:00,ACME-42,
+2024-06-04,,Tokyo,Shibuya,"tue,thu",2024-06-30,,,Jane;bob@example.com
+`,
+		},
+		{
+			name:          "csv unknown column",
+			parse:         parsePlanCSV,
+			input:         "date,desk\n2024-06-03,A1\n",
+			errorContains: "unknown column 'desk'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := tt.parse([]byte(tt.input))
+			if tt.errorContains != "" {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				if !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("expected error to contain '%s', got '%s'", tt.errorContains, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(entries, expected) {
+				t.Errorf("expected %+v, got %+v", expected, entries)
+			}
+		})
+	}
+}
+
+func TestParseTimeRange(t *testing.T) {
+	tests := []struct {
+		input         string
+		start, end    string
+		errorContains string
+	}{
+		{input: "09:00-13:00", start: "09:00", end: "13:00"},
+		{input: " 14:30 - 18:00 ", start: "14:30", end: "18:00"},
+		{input: "9:00-13:00", start: "09:00", end: "13:00"},
+		{input: "9:30-10:00", start: "09:30", end: "10:00"},
+		{input: "13:00-9:00", errorContains: "end must be after start"},
+		{input: "13:00-09:00", errorContains: "end must be after start"},
+		{input: "9am-1pm", errorContains: "expected HH:MM-HH:MM"},
+		{input: "09:00", errorContains: "expected HH:MM-HH:MM"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			start, end, err := parseTimeRange(tt.input)
+			if tt.errorContains != "" {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				if !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("expected error to contain '%s', got '%s'", tt.errorContains, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if start != tt.start || end != tt.end {
+				t.Errorf("expected %s-%s, got %s-%s", tt.start, tt.end, start, end)
+			}
+		})
+	}
+}
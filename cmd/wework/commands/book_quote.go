@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/wework"
+	"github.com/spf13/cobra"
+)
+
+// quoteFixedFlags are the book flags that a saved quote already determines.
+var quoteFixedFlags = []string{
+	"location-uuid", "city", "name", "space-uuid", "pick", "seats", "guest", "company", "membership",
+	"date", "every", "interval", "until", "count", "rrule", "tz", "weekdays-only", "holidays",
+	"segment", "multiday", "when-open", "horizon", "atomic", "plan", "dry-run",
+}
+
+// checkQuoteFlags rejects flags that conflict with booking a saved quote.
+func checkQuoteFlags(cmd *cobra.Command) error {
+	for _, name := range quoteFixedFlags {
+		if cmd.Flags().Changed(name) {
+			return fmt.Errorf("--quote cannot be combined with --%s, the quote fixes the space, date and attendees", name)
+		}
+	}
+	return nil
+}
+
+// checkQuotePrice fails if the re-issued quote's total moved by more than tolerance credits from
+// the saved quote's, or is charged at a different credit ratio.
+func checkQuotePrice(saved, fresh *wework.QuoteResponse, tolerance float64) error {
+	was, now := saved.GrandTotal, fresh.GrandTotal
+	if math.Abs(now.Amount-was.Amount) > tolerance {
+		return fmt.Errorf("price changed from %.2f to %.2f credits since quoted (tolerance %.2f)", was.Amount, now.Amount, tolerance)
+	}
+	if was.CreditRatio > 0 && now.CreditRatio > 0 && was.CreditRatio != now.CreditRatio {
+		return fmt.Errorf("credit ratio changed from %g to %g since quoted", was.CreditRatio, now.CreditRatio)
+	}
+	return nil
+}
+
+// bookQuoted books the space, window and attendees of a saved quote, failing if the quote has
+// expired. The booking API can't take a quote by reference, so the quote is issued again for
+// the same booking and used only if its total is within tolerance of the saved one.
+func bookQuoted(ww *wework.WeWork, progress progressReporter, saved *wework.SavedQuote, date time.Time, note string, replace []*wework.Booking, budget *creditBudget, tolerance float64) bookResult {
+	opts := saved.Options()
+	res := bookResult{Date: saved.Date, Seats: opts.SeatCount(), Guests: opts.Guests, Status: bookingFailed}
+
+	if saved.Expired(time.Now()) {
+		res.Error = fmt.Sprintf("quote %s expired at %s", saved.Quote.UUID, saved.ExpiresAt.Local().Format("2006-01-02 15:04"))
+		res.Hint = "Run `wework quote` again and book the new quote"
+		return res
+	}
+
+	sel := spaceSelector{SpaceUUID: saved.SpaceUUID, Seats: opts.Seats}
+	space, err := findSpace(ww, progress, sel, date, saved.LocationUUID)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	opts.Note = renderNote(note, date, space)
+	res.Note = opts.Note
+	if err := res.setSpace(date, space, opts); err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	progress.Update(fmt.Sprintf("%s: re-issuing quote %s…", saved.Date, saved.Quote.UUID))
+	quote, err := ww.GetBookingQuoteWithOptions(date, space, opts)
+	if err != nil {
+		res.Error = fmt.Sprintf("failed to get booking quote: %v", err)
+		res.classify(date, err.Error())
+		return res
+	}
+	if err := checkQuotePrice(saved.Quote, quote, tolerance); err != nil {
+		res.Error = err.Error()
+		res.Hint = "Run `wework quote` again to check the new price, or raise --price-tolerance"
+		return res
+	}
+
+	placeBooking(ww, progress, &res, date, space, quote, replace, opts, budget)
+	return res
+}
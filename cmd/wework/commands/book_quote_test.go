@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+func TestSavedQuoteRoundTrip(t *testing.T) {
+	t.Setenv("WEWORK_QUOTES_DIR", t.TempDir())
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	expired := &wework.SavedQuote{Quote: &wework.QuoteResponse{UUID: "old"}, ExpiresAt: now.Add(-time.Minute)}
+	if _, err := saveQuote(expired, now.Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	saved := &wework.SavedQuote{
+		Quote:     &wework.QuoteResponse{UUID: "q-1", GrandTotal: wework.Money{Amount: 2}},
+		Date:      "2026-03-16",
+		SpaceUUID: "space-1",
+		Seats:     2,
+		ExpiresAt: now.Add(wework.QuoteLifetime),
+	}
+	path, err := saveQuote(saved, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, ref := range []string{"q-1", path} {
+		got, err := loadQuote(ref)
+		if err != nil {
+			t.Fatalf("loadQuote(%q) error = %v", ref, err)
+		}
+		if got.Quote.UUID != "q-1" || got.SpaceUUID != "space-1" || got.Seats != 2 || got.Quote.GrandTotal.Amount != 2 {
+			t.Errorf("loadQuote(%q) = %+v", ref, got)
+		}
+	}
+	if _, err := loadQuote("old"); err == nil || !strings.Contains(err.Error(), "no saved quote old") {
+		t.Errorf("expired quote was not pruned: %v", err)
+	}
+}
+
+func TestCheckQuotePrice(t *testing.T) {
+	tests := []struct {
+		name          string
+		saved         wework.Money
+		fresh         wework.Money
+		tolerance     float64
+		errorContains string
+	}{
+		{name: "unchanged", saved: wework.Money{Amount: 2, CreditRatio: 30}, fresh: wework.Money{Amount: 2, CreditRatio: 30}},
+		{name: "price went up", saved: wework.Money{Amount: 2}, fresh: wework.Money{Amount: 3}, errorContains: "from 2.00 to 3.00 credits"},
+		{name: "within tolerance", saved: wework.Money{Amount: 2}, fresh: wework.Money{Amount: 3}, tolerance: 1},
+		{name: "price went down beyond tolerance", saved: wework.Money{Amount: 3}, fresh: wework.Money{Amount: 1}, tolerance: 1, errorContains: "from 3.00 to 1.00 credits"},
+		{name: "credit ratio changed", saved: wework.Money{Amount: 2, CreditRatio: 30}, fresh: wework.Money{Amount: 2, CreditRatio: 35}, errorContains: "credit ratio changed from 30 to 35"},
+		{name: "credit ratio missing", saved: wework.Money{Amount: 2}, fresh: wework.Money{Amount: 2, CreditRatio: 35}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkQuotePrice(&wework.QuoteResponse{GrandTotal: tt.saved}, &wework.QuoteResponse{GrandTotal: tt.fresh}, tt.tolerance)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing '%s', got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
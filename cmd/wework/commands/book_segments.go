@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/config"
+	"github.com/dvcrn/wework-cli/pkg/spinner"
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+// bookSegment is one part of a split day: a time range at a location.
+type bookSegment struct {
+	Location  string
+	StartTime string
+	EndTime   string
+
+	locationUUIDs []string
+}
+
+func (s bookSegment) String() string {
+	return fmt.Sprintf("%s %s-%s", s.Location, s.StartTime, s.EndTime)
+}
+
+// options returns opts booking only the segment's time range.
+func (s bookSegment) options(opts wework.BookingOptions) wework.BookingOptions {
+	opts.StartTime = s.StartTime
+	opts.EndTime = s.EndTime
+	return opts
+}
+
+// parseSegment parses a --segment value such as "LOCATION 09:00-13:00", where LOCATION is a
+// UUID, a comma-separated list or a config alias.
+func parseSegment(value string) (bookSegment, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return bookSegment{}, fmt.Errorf("invalid segment '%s' (expected \"LOCATION HH:MM-HH:MM\")", value)
+	}
+	start, end, err := parseTimeRange(fields[1])
+	if err != nil {
+		return bookSegment{}, fmt.Errorf("invalid segment '%s': %v", value, err)
+	}
+	return bookSegment{Location: fields[0], StartTime: start, EndTime: end}, nil
+}
+
+// parseSegments parses --segment values into segments ordered by start time, making sure
+// they don't overlap. parseTimeRange zero-pads the times, so they order correctly as strings.
+// Times are compared as given, so segments at locations in different timezones are assumed to be
+// on the same clock.
+func parseSegments(values []string) ([]bookSegment, error) {
+	var segments []bookSegment
+	for _, v := range values {
+		seg, err := parseSegment(v)
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, seg)
+	}
+
+	sort.SliceStable(segments, func(i, j int) bool { return segments[i].StartTime < segments[j].StartTime })
+	for i := 1; i < len(segments); i++ {
+		if prev := segments[i-1]; segments[i].StartTime < prev.EndTime {
+			return nil, fmt.Errorf("segments '%s' and '%s' overlap", prev, segments[i])
+		}
+	}
+	return segments, nil
+}
+
+// resolveSegments resolves the locations of each segment.
+func resolveSegments(ww *wework.WeWork, cfg *config.Config, segments []bookSegment) error {
+	for i := range segments {
+		uuids, err := resolveLocationUUIDs(ww, cfg, "", "", segments[i].Location)
+		if err != nil {
+			return fmt.Errorf("segment '%s': %v", segments[i], err)
+		}
+		segments[i].locationUUIDs = uuids
+	}
+	return nil
+}
+
+// segmentsClosedWeekdays returns the weekdays on which any segment's locations are closed,
+// since the split day can't be booked then.
+func segmentsClosedWeekdays(ww *wework.WeWork, segments []bookSegment) (map[time.Weekday]bool, error) {
+	closed := make(map[time.Weekday]bool)
+	for _, seg := range segments {
+		segmentClosed, err := closedWeekdays(ww, seg.locationUUIDs)
+		if err != nil {
+			return nil, err
+		}
+		for wd := range segmentClosed {
+			closed[wd] = true
+		}
+	}
+	return closed, nil
+}
+
+// resolveSegmentsWithProgress resolves the segments and the weekdays they can't be booked,
+// showing a spinner in text mode. Failing to read opening hours only means closed days aren't
+// skipped, so that error is returned separately as closedErr for the caller to warn about.
+func resolveSegmentsWithProgress(ww *wework.WeWork, cfg *config.Config, jsonOut bool, segments []bookSegment) (closed map[time.Weekday]bool, closedErr error, err error) {
+	if jsonOut {
+		if err := resolveSegments(ww, cfg, segments); err != nil {
+			return nil, nil, err
+		}
+		closed, closedErr = segmentsClosedWeekdays(ww, segments)
+		return closed, closedErr, nil
+	}
+
+	err = spinner.WithContinuousSpinner(func(cs *spinner.ContinuousSpinner) error {
+		cs.Update("Resolving segment locations…")
+		if err := resolveSegments(ww, cfg, segments); err != nil {
+			return err
+		}
+		cs.Update("Checking opening hours…")
+		closed, closedErr = segmentsClosedWeekdays(ww, segments)
+		cs.Success(fmt.Sprintf("Resolved %d segments", len(segments)))
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return closed, closedErr, nil
+}
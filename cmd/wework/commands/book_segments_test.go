@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+func TestParseSegments(t *testing.T) {
+	tests := []struct {
+		name        string
+		values      []string
+		expected    string
+		expectedErr string
+	}{
+		{name: "split day", values: []string{"A 09:00-13:00", "B 14:00-18:00"}, expected: "A 09:00-13:00|B 14:00-18:00"},
+		{name: "sorted by start", values: []string{"B 13:00-18:00", "A 09:00-13:00"}, expected: "A 09:00-13:00|B 13:00-18:00"},
+		{name: "single-digit hours sorted", values: []string{"B 10:00-12:00", "A 9:00-10:00"}, expected: "A 09:00-10:00|B 10:00-12:00"},
+		{name: "location list", values: []string{"uuid-1,uuid-2 09:00-12:00"}, expected: "uuid-1,uuid-2 09:00-12:00"},
+		{name: "overlap", values: []string{"A 09:00-13:00", "B 12:30-18:00"}, expectedErr: "overlap"},
+		{name: "single-digit hours overlap", values: []string{"A 9:00-11:00", "B 10:00-12:00"}, expectedErr: "overlap"},
+		{name: "missing time", values: []string{"A"}, expectedErr: "expected \"LOCATION HH:MM-HH:MM\""},
+		{name: "invalid time", values: []string{"A 13:00-09:00"}, expectedErr: "end must be after start"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			segments, err := parseSegments(tt.values)
+			if tt.expectedErr != "" {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				if !strings.Contains(err.Error(), tt.expectedErr) {
+					t.Errorf("expected error to contain '%s', got '%s'", tt.expectedErr, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []string
+			for _, seg := range segments {
+				got = append(got, seg.String())
+			}
+			if strings.Join(got, "|") != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, strings.Join(got, "|"))
+			}
+		})
+	}
+}
+
+func TestSegmentsBudget(t *testing.T) {
+	date := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	segments, err := parseSegments([]string{"loc1 09:00-13:00", "loc2 13:00-18:00"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		maxPerDay     float64
+		credits       float64
+		errorContains string
+	}{
+		{
+			name:      "split day within the limit",
+			maxPerDay: 6,
+			credits:   3,
+		},
+		{
+			name:          "segments together over the limit",
+			maxPerDay:     5,
+			credits:       3,
+			errorContains: "would bring 2024-06-03 to 6.00, over the limit of 5.00 per day",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budget, err := newCreditBudget(tt.maxPerDay, 0, 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// Each segment is checked and committed on its own, as when booking
+			var errs []string
+			for _, seg := range segments {
+				days := spanDays(date, seg.options(wework.BookingOptions{}).EndDate)
+				if err := budget.checkDays(days, tt.credits); err != nil {
+					errs = append(errs, err.Error())
+					continue
+				}
+				budget.commitDays(days, tt.credits)
+			}
+
+			if tt.errorContains != "" {
+				if len(errs) != 1 {
+					t.Fatalf("expected the second segment to fail, got %v", errs)
+				}
+				if !strings.Contains(errs[0], tt.errorContains) {
+					t.Errorf("expected error to contain '%s', got '%s'", tt.errorContains, errs[0])
+				}
+				return
+			}
+			if len(errs) > 0 {
+				t.Fatalf("unexpected errors: %v", errs)
+			}
+		})
+	}
+}
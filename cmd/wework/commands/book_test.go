@@ -1,6 +1,9 @@
 package commands
 
 import (
+	"errors"
+	"io"
+	"os"
 	"strings"
 	"testing"
 
@@ -76,3 +79,110 @@ func TestFindLocationByFuzzyName(t *testing.T) {
 		})
 	}
 }
+
+func TestBookingOptions(t *testing.T) {
+	tests := []struct {
+		name          string
+		seats         int
+		guests        []string
+		expectedSeats int
+		expected      []wework.Guest
+		errorContains string
+	}{
+		{
+			name:          "defaults to one seat",
+			expectedSeats: 1,
+		},
+		{
+			name:          "one seat per guest",
+			guests:        []string{"Jane Doe <jane@example.com>", "bob@example.com", "Alice"},
+			expectedSeats: 4,
+			expected: []wework.Guest{
+				{Name: "Jane Doe", Email: "jane@example.com"},
+				{Email: "bob@example.com"},
+				{Name: "Alice"},
+			},
+		},
+		{
+			name:          "explicit seats",
+			seats:         3,
+			guests:        []string{"Alice"},
+			expectedSeats: 3,
+			expected:      []wework.Guest{{Name: "Alice"}},
+		},
+		{
+			name:          "too few seats for guests",
+			seats:         1,
+			guests:        []string{"Alice"},
+			errorContains: "too few",
+		},
+		{
+			name:          "invalid email",
+			guests:        []string{"not an @ email"},
+			errorContains: "invalid guest email",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := bookingOptions(tt.seats, tt.guests)
+			if tt.errorContains != "" {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				if !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("expected error to contain '%s', got '%s'", tt.errorContains, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if opts.Seats != tt.expectedSeats {
+				t.Errorf("expected %d seats, got %d", tt.expectedSeats, opts.Seats)
+			}
+			if len(opts.Guests) != len(tt.expected) {
+				t.Fatalf("expected %d guests, got %d", len(tt.expected), len(opts.Guests))
+			}
+			for i, g := range opts.Guests {
+				if g != tt.expected[i] {
+					t.Errorf("guest %d: expected %+v, got %+v", i, tt.expected[i], g)
+				}
+			}
+		})
+	}
+}
+
+func TestWithBookProgressJSON(t *testing.T) {
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	res := withBookProgress(true, func(progress progressReporter) bookResult {
+		if _, ok := progress.(quietProgress); !ok {
+			t.Errorf("expected quietProgress in JSON mode, got %T", progress)
+		}
+		progress.Update("finding available spaces…")
+		promptErr := errors.New("no space selected")
+		if err := progress.Suspend(func() error { return promptErr }); err != promptErr {
+			t.Errorf("expected Suspend to return the error of fn, got %v", err)
+		}
+		return bookResult{Date: "2024-06-03", Error: "booking failed"}
+	})
+
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) > 0 {
+		t.Errorf("expected nothing written to stdout in JSON mode, got %q", out)
+	}
+	if res.Date != "2024-06-03" || res.Error != "booking failed" {
+		t.Errorf("expected the result of book, got %+v", res)
+	}
+}
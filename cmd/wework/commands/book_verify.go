@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/spinner"
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+// bookingUnverified is the status of a booking that was accepted but doesn't show up among upcoming bookings.
+const bookingUnverified = "unverified"
+
+const (
+	verifyAttempts   = 3
+	verifyRetryDelay = 5 * time.Second
+)
+
+// shouldVerify reports whether bookings are verified: as set by --verify, or by default when booking several dates.
+func shouldVerify(verify *bool, dates int) bool {
+	if verify != nil {
+		return *verify
+	}
+	return dates > 1
+}
+
+// verifyBookings checks that every accepted booking in results shows up among upcoming bookings,
+// retrying a few times since new bookings can take a moment to appear. Bookings that never show
+// up, or can't be checked, are marked unverified. Results are updated in place; if upcoming
+// bookings can't be fetched, the error is returned.
+func verifyBookings(ww *wework.WeWork, jsonOut bool, results []*bookResult) error {
+	var missing []*bookResult
+	for _, res := range results {
+		if res.Status == wework.BookingConfirmed || res.Status == wework.BookingPending {
+			missing = append(missing, res)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	verify := func(progress progressReporter) error {
+		for attempt := 1; ; attempt++ {
+			progress.Update(fmt.Sprintf("Verifying %d booking(s)…", len(missing)))
+			upcoming, err := ww.GetUpcomingBookings()
+			if err != nil {
+				for _, res := range missing {
+					res.Status = bookingUnverified
+				}
+				return fmt.Errorf("could not verify bookings: failed to get upcoming bookings: %v", err)
+			}
+
+			var stillMissing []*bookResult
+			for _, res := range missing {
+				if findBooking(upcoming, *res) == nil {
+					stillMissing = append(stillMissing, res)
+				}
+			}
+			missing = stillMissing
+
+			if len(missing) == 0 || attempt == verifyAttempts {
+				break
+			}
+			time.Sleep(verifyRetryDelay)
+		}
+		for _, res := range missing {
+			res.Status = bookingUnverified
+		}
+		return nil
+	}
+
+	if jsonOut {
+		return verify(quietProgress{})
+	}
+
+	return spinner.WithContinuousSpinner(func(cs *spinner.ContinuousSpinner) error {
+		if err := verify(cs); err != nil {
+			return err
+		}
+		for _, res := range missing {
+			cs.Printf("   ⚠️  %s: booking %s doesn't show up among upcoming bookings, check the app\n", res.Date, res.BookingStatus.ReservationID)
+		}
+		if len(missing) > 0 {
+			cs.Success(fmt.Sprintf("%d booking(s) could not be verified", len(missing)))
+			return nil
+		}
+		cs.Success("Verified bookings")
+		return nil
+	})
+}
+
+// verifyWarning describes a failed verification; the bookings are reported as unverified.
+func verifyWarning(err error) string {
+	return fmt.Sprintf("%v; bookings are reported as unverified", err)
+}
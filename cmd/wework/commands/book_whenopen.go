@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/spinner"
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+const (
+	// openRetryFor is how long bookings are retried after the window should have opened,
+	// in case the location's clock runs a little behind.
+	openRetryFor      = time.Minute
+	openRetryInterval = 2 * time.Second
+)
+
+// bookingOpensAt returns when bookings for date open with a horizon of days: midnight at the
+// location, days before date. Dates are in the location's timezone.
+func bookingOpensAt(date time.Time, days int) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day()-days, 0, 0, 0, 0, date.Location())
+}
+
+// detectHorizon quotes the last of dates at the first location to find how many days ahead
+// bookings open there. It returns 0 when the quote succeeds, i.e. every date can be booked now.
+func detectHorizon(ww *wework.WeWork, progress progressReporter, sel spaceSelector, dates []time.Time, locationUUIDs []string, opts wework.BookingOptions) (int, error) {
+	last := dates[len(dates)-1]
+	progress.Update("Fetching location details…")
+	res, err := ww.GetSpacesByUUIDs(locationUUIDs[:1])
+	if err != nil {
+		return 0, fmt.Errorf("failed to get location details: %v", err)
+	}
+	if len(res.Response.Workspaces) == 0 {
+		return 0, fmt.Errorf("no spaces found for location UUID %s", locationUUIDs[0])
+	}
+	space := &res.Response.Workspaces[0]
+	for i := range res.Response.Workspaces {
+		if res.Response.Workspaces[i].UUID == sel.SpaceUUID {
+			space = &res.Response.Workspaces[i]
+		}
+	}
+
+	progress.Update(fmt.Sprintf("Checking whether %s can be booked yet…", last.Format("2006-01-02")))
+	_, err = ww.GetBookingQuoteWithOptions(last, space, opts)
+	if err == nil {
+		return 0, nil
+	}
+	herr, ok := wework.ParseHorizon(err.Error())
+	if !ok || herr.Days == 0 {
+		return 0, fmt.Errorf("could not detect the booking horizon of %s (quote failed: %v); pass --horizon DAYS", space.Location.Name, err)
+	}
+	return herr.Days, nil
+}
+
+// detectHorizonWithProgress runs detectHorizon, with a spinner in text mode.
+func detectHorizonWithProgress(ww *wework.WeWork, jsonOut bool, sel spaceSelector, dates []time.Time, locationUUIDs []string, opts wework.BookingOptions) (int, error) {
+	if jsonOut {
+		return detectHorizon(ww, quietProgress{}, sel, dates, locationUUIDs, opts)
+	}
+
+	var days int
+	err := spinner.WithContinuousSpinner(func(cs *spinner.ContinuousSpinner) error {
+		d, err := detectHorizon(ww, cs, sel, dates, locationUUIDs, opts)
+		if err != nil {
+			return err
+		}
+		days = d
+		if days == 0 {
+			cs.Success("All dates can be booked now")
+		} else {
+			cs.Success(fmt.Sprintf("Bookings open %d days ahead", days))
+		}
+		return nil
+	})
+	return days, err
+}
+
+// bookWhenOpen waits until bookings for date open and books it right away. Bookings rejected as
+// beyond the horizon are retried for a short while in case the window opens a little late.
+func bookWhenOpen(ctx context.Context, ww *wework.WeWork, jsonOut bool, sel spaceSelector, date time.Time, locationUUIDs []string, replace []*wework.Booking, opts wework.BookingOptions, budget *creditBudget, horizon int) bookResult {
+	dateStr := date.Format("2006-01-02")
+	if horizon > 0 {
+		opensAt := bookingOpensAt(date, horizon)
+		if wait := time.Until(opensAt); wait > 0 {
+			if !jsonOut {
+				when := opensAt.Format("2006-01-02 15:04 MST")
+				if local := opensAt.Local(); local.Format("-0700") != opensAt.Format("-0700") {
+					when += local.Format(" (2006-01-02 15:04 MST local)")
+				}
+				fmt.Printf("⏰ %s: bookings open at %s, waiting %s…\n", dateStr, when, wait.Round(time.Second))
+			}
+			if err := sleepContext(ctx, wait); err != nil {
+				return bookResult{Date: dateStr, Status: bookingFailed, Error: "interrupted while waiting for bookings to open"}
+			}
+		}
+	}
+
+	return withBookProgress(jsonOut, func(progress progressReporter) bookResult {
+		deadline := time.Now().Add(openRetryFor)
+		var replaced []string
+		for {
+			res := bookDate(ww, progress, sel, date, locationUUIDs, replace, opts, budget)
+			// Replaced bookings are cancelled before booking, so don't cancel them again on retries
+			replaced = append(replaced, res.Replaced...)
+			res.Replaced = replaced
+			if len(replaced) > 0 {
+				replace = nil
+			}
+			if res.horizon == nil || time.Now().After(deadline) {
+				return res
+			}
+			progress.Update(fmt.Sprintf("%s: not bookable yet, retrying…", dateStr))
+			if err := sleepContext(ctx, openRetryInterval); err != nil {
+				return res
+			}
+		}
+	})
+}
+
+// sleepContext sleeps for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBookingOpensAt(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		date     time.Time
+		days     int
+		expected string
+	}{
+		{date: time.Date(2026, 3, 15, 0, 0, 0, 0, tokyo), days: 14, expected: "2026-03-01 00:00 JST"},
+		{date: time.Date(2026, 3, 15, 0, 0, 0, 0, tokyo), days: 30, expected: "2026-02-13 00:00 JST"},
+		{date: time.Date(2026, 1, 5, 0, 0, 0, 0, tokyo), days: 7, expected: "2025-12-29 00:00 JST"},
+	}
+
+	for _, tt := range tests {
+		if got := bookingOpensAt(tt.date, tt.days).Format("2006-01-02 15:04 MST"); got != tt.expected {
+			t.Errorf("bookingOpensAt(%s, %d) = %s, want %s", tt.date.Format("2006-01-02"), tt.days, got, tt.expected)
+		}
+	}
+}
@@ -7,13 +7,37 @@ import (
 	"time"
 
 	"github.com/dvcrn/wework-cli/pkg/spinner"
+	"github.com/dvcrn/wework-cli/pkg/tzdate"
 	"github.com/dvcrn/wework-cli/pkg/wework"
 	"github.com/spf13/cobra"
 )
 
+// pastBookingsRange parses the --start-date/--end-date flags, defaulting to the last 30 days.
+// Both accept relative dates; the end date is inclusive.
+func pastBookingsRange(startDate, endDate string) (time.Time, time.Time, error) {
+	now := time.Now()
+	start := now.AddDate(0, 0, -30)
+	end := now
+	if startDate != "" {
+		dates, err := tzdate.ParseDates(startDate, now)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid start date: %v", err)
+		}
+		start = dates[0]
+	}
+	if endDate != "" {
+		dates, err := tzdate.ParseDates(endDate, now)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid end date: %v", err)
+		}
+		end = dates[len(dates)-1].AddDate(0, 0, 1)
+	}
+	return start, end, nil
+}
+
 func NewBookingsCommand(authenticate func() (*wework.WeWork, error)) *cobra.Command {
 	var past bool
-	var startDate, endDate string
+	var startDate, endDate, status string
 
 	cmd := &cobra.Command{
 		Use:   "bookings",
@@ -25,6 +49,12 @@ func NewBookingsCommand(authenticate func() (*wework.WeWork, error)) *cobra.Comm
 				return err
 			}
 
+			switch status {
+			case "", wework.BookingPending, wework.BookingConfirmed:
+			default:
+				return fmt.Errorf("invalid --status value %q (expected %s or %s)", status, wework.BookingPending, wework.BookingConfirmed)
+			}
+
 			var bookings []*wework.Booking
 			var bookingType string
 
@@ -33,22 +63,9 @@ func NewBookingsCommand(authenticate func() (*wework.WeWork, error)) *cobra.Comm
 				if past {
 					bookingType = "past"
 					if startDate != "" || endDate != "" {
-						var start, end time.Time
-						if startDate != "" {
-							start, err = time.Parse("2006-01-02", startDate)
-							if err != nil {
-								return fmt.Errorf("invalid start date format: %v", err)
-							}
-						} else {
-							start = time.Now().AddDate(0, 0, -30)
-						}
-						if endDate != "" {
-							end, err = time.Parse("2006-01-02", endDate)
-							if err != nil {
-								return fmt.Errorf("invalid end date format: %v", err)
-							}
-						} else {
-							end = time.Now()
+						start, end, err := pastBookingsRange(startDate, endDate)
+						if err != nil {
+							return err
 						}
 						bookings, err = ww.GetPastBookingsWithDates(start, end)
 						if err != nil {
@@ -72,22 +89,9 @@ func NewBookingsCommand(authenticate func() (*wework.WeWork, error)) *cobra.Comm
 				if past {
 					bookingType = "past"
 					if startDate != "" || endDate != "" {
-						var start, end time.Time
-						if startDate != "" {
-							start, err = time.Parse("2006-01-02", startDate)
-							if err != nil {
-								return fmt.Errorf("invalid start date format: %v", err)
-							}
-						} else {
-							start = time.Now().AddDate(0, 0, -30)
-						}
-						if endDate != "" {
-							end, err = time.Parse("2006-01-02", endDate)
-							if err != nil {
-								return fmt.Errorf("invalid end date format: %v", err)
-							}
-						} else {
-							end = time.Now()
+						start, end, err := pastBookingsRange(startDate, endDate)
+						if err != nil {
+							return err
 						}
 
 						if err := spinner.WithContinuousSpinner(func(cs *spinner.ContinuousSpinner) error {
@@ -133,31 +137,74 @@ func NewBookingsCommand(authenticate func() (*wework.WeWork, error)) *cobra.Comm
 				}
 			}
 
+			if status != "" {
+				var filtered []*wework.Booking
+				for _, booking := range bookings {
+					if booking.Status() == status {
+						filtered = append(filtered, booking)
+					}
+				}
+				bookings = filtered
+				bookingType = status + " " + bookingType
+			}
+
+			// Look up who paid for each booking among the member's companies and memberships
+			var payers *wework.AppBootstrapResponse
+			if len(bookings) > 0 {
+				jsonOut, _ := cmd.Flags().GetBool("json")
+				payers = loadPayers(ww, jsonOut)
+			}
+			payerOf := func(booking *wework.Booking) *wework.Payer {
+				if payers == nil {
+					return nil
+				}
+				if payer, ok := payers.PayerOf(booking); ok {
+					return &payer
+				}
+				return nil
+			}
+
 			if jsonOut, _ := cmd.Flags().GetBool("json"); jsonOut {
 				type compactBooking struct {
-					UUID         string `json:"uuid"`
-					Date         string `json:"date"`
-					StartTime    string `json:"startTime"`
-					EndTime      string `json:"endTime"`
-					LocationName string `json:"locationName"`
-					LocationUUID string `json:"locationUUID"`
-					Address      string `json:"address"`
-					City         string `json:"city"`
-					Credits      string `json:"credits"`
+					UUID         string         `json:"uuid"`
+					Date         string         `json:"date"`
+					EndDate      string         `json:"endDate,omitempty"`
+					Multiday     bool           `json:"multiday,omitempty"`
+					StartTime    string         `json:"startTime"`
+					EndTime      string         `json:"endTime"`
+					LocationName string         `json:"locationName"`
+					LocationUUID string         `json:"locationUUID"`
+					Address      string         `json:"address"`
+					City         string         `json:"city"`
+					Status       string         `json:"status"`
+					Credits      string         `json:"credits"`
+					Guests       []wework.Guest `json:"guests,omitempty"`
+					Note         string         `json:"note,omitempty"`
+					Payer        *wework.Payer  `json:"payer,omitempty"`
 				}
 
 				var compact []compactBooking
 				for _, booking := range bookings {
+					var endDate string
+					if days := booking.Days(); len(days) > 1 {
+						endDate = days[len(days)-1].Format("2006-01-02")
+					}
 					compact = append(compact, compactBooking{
 						UUID:         booking.UUID,
 						Date:         booking.StartsAt.Time.Format("2006-01-02"),
+						EndDate:      endDate,
+						Multiday:     booking.IsMultidayBooking,
 						StartTime:    booking.StartsAt.Time.Format("15:04"),
 						EndTime:      booking.EndsAt.Time.Format("15:04"),
 						LocationName: booking.Reservable.Location.Name,
 						LocationUUID: booking.Reservable.Location.UUID,
 						Address:      booking.Reservable.Location.Address.Line1,
 						City:         booking.Reservable.Location.Address.City,
+						Status:       booking.Status(),
 						Credits:      booking.CreditOrder.Price,
+						Guests:       booking.Guests,
+						Note:         booking.Notes,
+						Payer:        payerOf(booking),
 					})
 				}
 
@@ -174,8 +221,8 @@ func NewBookingsCommand(authenticate func() (*wework.WeWork, error)) *cobra.Comm
 				return nil
 			}
 
-			fmt.Printf("%-20s%-25s%-30s%-40s%s\n", "Date", "Time", "Location", "Address", "Credits Used")
-			fmt.Println(strings.Repeat("-", 145))
+			fmt.Printf("%-20s%-25s%-30s%-40s%-12s%s\n", "Date", "Time", "Location", "Address", "Status", "Credits Used")
+			fmt.Println(strings.Repeat("-", 157))
 			for _, booking := range bookings {
 				localStartsAt := booking.StartsAt.Time
 				localEndsAt := booking.EndsAt.Time
@@ -184,6 +231,7 @@ func NewBookingsCommand(authenticate func() (*wework.WeWork, error)) *cobra.Comm
 					localEndsAt.Format("15:04 (MST)"))
 				isToday := localStartsAt.Format("2006-01-02") == time.Now().Format("2006-01-02")
 				dateWithDay := localStartsAt.Format("2006-01-02 Mon")
+				days := booking.Days()
 				if isToday && !past {
 					dateWithDay += " *"
 				}
@@ -195,20 +243,39 @@ func NewBookingsCommand(authenticate func() (*wework.WeWork, error)) *cobra.Comm
 				if len(address) > 38 {
 					address = address[:38]
 				}
-				fmt.Printf("%-20s%-25s%-30s%-40s%s\n",
+				bookingStatus := booking.Status()
+				if bookingStatus == wework.BookingPending {
+					bookingStatus = "⏳ pending"
+				}
+				fmt.Printf("%-20s%-25s%-30s%-40s%-12s%s\n",
 					dateWithDay,
 					timeRange,
 					name,
 					address,
+					bookingStatus,
 					booking.CreditOrder.Price)
+				if len(days) > 1 {
+					fmt.Printf("%-20sMulti-day: %d days through %s\n", "", len(days), days[len(days)-1].Format("2006-01-02 Mon"))
+				}
+				// Upcoming bookings don't report a seat count, so only the guests are shown
+				if len(booking.Guests) > 0 {
+					fmt.Printf("%-20sGuests: %s\n", "", formatGuests(booking.Guests))
+				}
+				if booking.Notes != "" {
+					fmt.Printf("%-20sNote: %s\n", "", booking.Notes)
+				}
+				if payer := payerOf(booking); payer != nil {
+					fmt.Printf("%-20sPaid by: %s\n", "", payer)
+				}
 			}
 			return nil
 		},
 	}
 
 	cmd.Flags().BoolVar(&past, "past", false, "Show past bookings instead of upcoming")
-	cmd.Flags().StringVar(&startDate, "start-date", "", "Start date for past bookings (YYYY-MM-DD)")
-	cmd.Flags().StringVar(&endDate, "end-date", "", "End date for past bookings (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&status, "status", "", "Only show bookings with this status: pending or confirmed")
+	cmd.Flags().StringVar(&startDate, "start-date", "", "Start date for past bookings (YYYY-MM-DD or relative, e.g. last week, -14d)")
+	cmd.Flags().StringVar(&endDate, "end-date", "", "End date for past bookings, inclusive (YYYY-MM-DD or relative, e.g. yesterday)")
 
 	return cmd
 }
@@ -0,0 +1,266 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+// What --over-budget does when a quote would exceed a credit limit.
+const (
+	overBudgetAbort = "abort"
+	overBudgetSkip  = "skip"
+)
+
+// creditBudget enforces credit limits on a run of bookings. A zero limit is not enforced.
+type creditBudget struct {
+	MaxPerDay float64
+	MaxTotal  float64
+	Monthly   float64
+
+	spent float64
+	// perDay holds credits spent in this run per date ("2006-01-02"), checked against MaxPerDay
+	perDay map[string]float64
+	// used holds credits per month ("2006-01"), including existing bookings when Monthly is set
+	used map[string]float64
+	// counted holds the existing bookings included in used, by booking UUID
+	counted map[string]existingCharge
+}
+
+// existingCharge is what an existing booking adds to the monthly usage.
+type existingCharge struct {
+	month   string
+	credits float64
+}
+
+func newCreditBudget(maxPerDay, maxTotal, monthly float64) (*creditBudget, error) {
+	if maxPerDay < 0 || maxTotal < 0 || monthly < 0 {
+		return nil, fmt.Errorf("credit limits must not be negative")
+	}
+	return &creditBudget{MaxPerDay: maxPerDay, MaxTotal: maxTotal, Monthly: monthly, perDay: make(map[string]float64), used: make(map[string]float64)}, nil
+}
+
+func validateOverBudget(overBudget string) error {
+	switch overBudget {
+	case overBudgetAbort, overBudgetSkip:
+		return nil
+	}
+	return fmt.Errorf("invalid --over-budget value %q (expected %s or %s)", overBudget, overBudgetAbort, overBudgetSkip)
+}
+
+// limited reports whether any limit is set.
+func (b *creditBudget) limited() bool {
+	return b != nil && (b.MaxPerDay > 0 || b.MaxTotal > 0 || b.Monthly > 0)
+}
+
+// addExisting counts credits of already existing bookings towards their month. A booking is
+// counted once, even if it is passed again, e.g. as both past and upcoming.
+func (b *creditBudget) addExisting(bookings []*wework.Booking) {
+	for _, booking := range bookings {
+		if booking == nil || booking.CreditOrder == nil || booking.StartsAt.IsZero() {
+			continue
+		}
+		if _, ok := b.counted[booking.UUID]; ok {
+			continue
+		}
+		credits, err := strconv.ParseFloat(strings.TrimSpace(booking.CreditOrder.Price), 64)
+		if err != nil {
+			continue
+		}
+		charge := existingCharge{month: booking.StartsAt.Time.Format("2006-01"), credits: credits}
+		if b.used == nil {
+			b.used = make(map[string]float64)
+		}
+		b.used[charge.month] += charge.credits
+		if booking.UUID != "" {
+			if b.counted == nil {
+				b.counted = make(map[string]existingCharge)
+			}
+			b.counted[booking.UUID] = charge
+		}
+	}
+}
+
+// release stops counting existing bookings, e.g. once they are cancelled to be replaced.
+func (b *creditBudget) release(bookings []*wework.Booking) {
+	if b == nil {
+		return
+	}
+	for _, booking := range bookings {
+		if booking == nil {
+			continue
+		}
+		charge, ok := b.counted[booking.UUID]
+		if !ok {
+			continue
+		}
+		b.used[charge.month] -= charge.credits
+		delete(b.counted, booking.UUID)
+	}
+}
+
+// without returns a copy that no longer counts bookings, e.g. those about to be replaced.
+func (b *creditBudget) without(bookings []*wework.Booking) *creditBudget {
+	c := b.clone()
+	c.release(bookings)
+	return c
+}
+
+// loadUsage counts existing bookings towards the monthly budget, if one is set. Upcoming
+// bookings are passed in; this month's past bookings are fetched. Bookings returned by both
+// are counted once.
+func (b *creditBudget) loadUsage(ww *wework.WeWork, upcoming []*wework.Booking) error {
+	if b == nil || b.Monthly <= 0 {
+		return nil
+	}
+	// Later months only have upcoming bookings
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	past, err := ww.GetPastBookingsWithDates(monthStart, now)
+	if err != nil {
+		return fmt.Errorf("failed to get past bookings for the monthly budget: %v", err)
+	}
+	b.addExisting(past)
+	b.addExisting(upcoming)
+	return nil
+}
+
+// clone returns an independent copy, e.g. to project spending without recording it.
+func (b *creditBudget) clone() *creditBudget {
+	if b == nil {
+		return nil
+	}
+	c := *b
+	c.perDay = make(map[string]float64, len(b.perDay))
+	for k, v := range b.perDay {
+		c.perDay[k] = v
+	}
+	c.used = make(map[string]float64, len(b.used))
+	for k, v := range b.used {
+		c.used[k] = v
+	}
+	c.counted = make(map[string]existingCharge, len(b.counted))
+	for k, v := range b.counted {
+		c.counted[k] = v
+	}
+	return &c
+}
+
+// check returns an error if booking date for the given credits would exceed a limit.
+func (b *creditBudget) check(date time.Time, credits float64) error {
+	if b == nil {
+		return nil
+	}
+	// A date can be booked in several parts, e.g. segments of a split day
+	day := date.Format("2006-01-02")
+	if b.MaxPerDay > 0 && b.perDay[day]+credits > b.MaxPerDay {
+		if b.perDay[day] > 0 {
+			return fmt.Errorf("over budget: %.2f credits would bring %s to %.2f, over the limit of %.2f per day", credits, day, b.perDay[day]+credits, b.MaxPerDay)
+		}
+		return fmt.Errorf("over budget: %.2f credits exceeds the limit of %.2f per day", credits, b.MaxPerDay)
+	}
+	if b.MaxTotal > 0 && b.spent+credits > b.MaxTotal {
+		return fmt.Errorf("over budget: %.2f credits would bring the total to %.2f of %.2f", credits, b.spent+credits, b.MaxTotal)
+	}
+	month := date.Format("2006-01")
+	if b.Monthly > 0 && b.used[month]+credits > b.Monthly {
+		return fmt.Errorf("over budget: %.2f credits would bring %s to %.2f of the %.2f monthly budget", credits, month, b.used[month]+credits, b.Monthly)
+	}
+	return nil
+}
+
+// commit records credits spent on date.
+func (b *creditBudget) commit(date time.Time, credits float64) {
+	if b == nil {
+		return
+	}
+	b.spent += credits
+	if b.perDay == nil {
+		b.perDay = make(map[string]float64)
+	}
+	b.perDay[date.Format("2006-01-02")] += credits
+	b.used[date.Format("2006-01")] += credits
+}
+
+// checkDays is check for credits spread evenly over days, e.g. of a multi-day booking.
+func (b *creditBudget) checkDays(days []time.Time, credits float64) error {
+	if b == nil {
+		return nil
+	}
+	probe := b.clone()
+	share := credits / float64(len(days))
+	for _, d := range days {
+		if err := probe.check(d, share); err != nil {
+			return err
+		}
+		probe.commit(d, share)
+	}
+	return nil
+}
+
+// commitDays records credits spent evenly over days.
+func (b *creditBudget) commitDays(days []time.Time, credits float64) {
+	share := credits / float64(len(days))
+	for _, d := range days {
+		b.commit(d, share)
+	}
+}
+
+// budgetReport summarises spending against the limits.
+type budgetReport struct {
+	Spent     float64       `json:"spent"`
+	MaxPerDay float64       `json:"maxPerDay,omitempty"`
+	MaxTotal  float64       `json:"maxTotal,omitempty"`
+	Months    []monthBudget `json:"months,omitempty"`
+}
+
+type monthBudget struct {
+	Month     string  `json:"month"`
+	Used      float64 `json:"used"`
+	Budget    float64 `json:"budget"`
+	Remaining float64 `json:"remaining"`
+}
+
+// report describes spending for the months covered by dates.
+func (b *creditBudget) report(dates []time.Time) budgetReport {
+	r := budgetReport{Spent: b.spent, MaxPerDay: b.MaxPerDay, MaxTotal: b.MaxTotal}
+	if b.Monthly <= 0 {
+		return r
+	}
+
+	seen := make(map[string]bool)
+	var months []string
+	for _, d := range dates {
+		month := d.Format("2006-01")
+		if !seen[month] {
+			seen[month] = true
+			months = append(months, month)
+		}
+	}
+	sort.Strings(months)
+	for _, month := range months {
+		r.Months = append(r.Months, monthBudget{
+			Month:     month,
+			Used:      b.used[month],
+			Budget:    b.Monthly,
+			Remaining: b.Monthly - b.used[month],
+		})
+	}
+	return r
+}
+
+// format renders the report for text output; label describes the spent amount, e.g. "Spent" or "Projected".
+func (r budgetReport) format(label string) string {
+	lines := []string{fmt.Sprintf("%s: %.2f credits", label, r.Spent)}
+	if r.MaxTotal > 0 {
+		lines[0] += fmt.Sprintf(" of %.2f allowed", r.MaxTotal)
+	}
+	for _, m := range r.Months {
+		lines = append(lines, fmt.Sprintf("Budget %s: %.2f of %.2f used, %.2f remaining", m.Month, m.Used, m.Budget, m.Remaining))
+	}
+	return strings.Join(lines, "\n")
+}
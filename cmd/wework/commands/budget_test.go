@@ -0,0 +1,178 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+func TestCreditBudgetCheck(t *testing.T) {
+	june := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	july := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		budget        creditBudget
+		spent         map[time.Time]float64
+		date          time.Time
+		credits       float64
+		errorContains string
+	}{
+		{
+			name:    "no limits",
+			date:    june,
+			credits: 100,
+		},
+		{
+			name:          "per day limit",
+			budget:        creditBudget{MaxPerDay: 2},
+			date:          june,
+			credits:       3,
+			errorContains: "per day",
+		},
+		{
+			name:    "within total",
+			budget:  creditBudget{MaxTotal: 5},
+			spent:   map[time.Time]float64{june: 3},
+			date:    july,
+			credits: 2,
+		},
+		{
+			name:          "over total",
+			budget:        creditBudget{MaxTotal: 5},
+			spent:         map[time.Time]float64{june: 3},
+			date:          july,
+			credits:       3,
+			errorContains: "total to 6.00 of 5.00",
+		},
+		{
+			name:          "over monthly budget",
+			budget:        creditBudget{Monthly: 10},
+			spent:         map[time.Time]float64{june: 9},
+			date:          june,
+			credits:       2,
+			errorContains: "2024-06",
+		},
+		{
+			name:    "monthly budget is per month",
+			budget:  creditBudget{Monthly: 10},
+			spent:   map[time.Time]float64{june: 9},
+			date:    july,
+			credits: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := tt.budget
+			b.used = make(map[string]float64)
+			for d, credits := range tt.spent {
+				b.commit(d, credits)
+			}
+
+			err := b.check(tt.date, tt.credits)
+			if tt.errorContains != "" {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				if !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("expected error to contain '%s', got '%s'", tt.errorContains, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCreditBudgetUsage(t *testing.T) {
+	june := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	booking := func(uuid string, day int, price string) *wework.Booking {
+		return &wework.Booking{
+			UUID:        uuid,
+			StartsAt:    wework.CustomTime{Time: time.Date(2024, 6, day, 9, 0, 0, 0, time.UTC)},
+			CreditOrder: &wework.CreditOrder{Price: price},
+		}
+	}
+	today := booking("today", 3, "4")
+
+	tests := []struct {
+		name          string
+		past          []*wework.Booking
+		upcoming      []*wework.Booking
+		replace       []*wework.Booking
+		credits       float64
+		used          float64
+		errorContains string
+	}{
+		{
+			name:     "past and upcoming",
+			past:     []*wework.Booking{booking("earlier", 1, "3")},
+			upcoming: []*wework.Booking{today},
+			credits:  3,
+			used:     7,
+		},
+		{
+			name:     "booking in past and upcoming counts once",
+			past:     []*wework.Booking{today},
+			upcoming: []*wework.Booking{today},
+			credits:  6,
+			used:     4,
+		},
+		{
+			name:          "over monthly budget",
+			upcoming:      []*wework.Booking{today, booking("later", 20, "4")},
+			credits:       3,
+			used:          8,
+			errorContains: "2024-06",
+		},
+		{
+			name:     "replaced booking does not count",
+			upcoming: []*wework.Booking{today, booking("later", 20, "4")},
+			replace:  []*wework.Booking{today},
+			credits:  3,
+			used:     4,
+		},
+		{
+			name:     "unknown replaced booking is ignored",
+			upcoming: []*wework.Booking{today},
+			replace:  []*wework.Booking{booking("other", 3, "4"), nil},
+			credits:  6,
+			used:     4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := newCreditBudget(0, 0, 10)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			b.addExisting(tt.past)
+			b.addExisting(tt.upcoming)
+
+			projected := b.without(tt.replace)
+			if got := projected.used["2024-06"]; got != tt.used {
+				t.Errorf("expected %.2f credits used, got %.2f", tt.used, got)
+			}
+
+			err = projected.check(june, tt.credits)
+			if tt.errorContains != "" {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				if !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("expected error to contain '%s', got '%s'", tt.errorContains, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/spinner"
+	"github.com/dvcrn/wework-cli/pkg/tzdate"
+	"github.com/dvcrn/wework-cli/pkg/wework"
+	"github.com/spf13/cobra"
+)
+
+// cancelResult is the outcome of cancelling one booking.
+type cancelResult struct {
+	BookingUUID  string `json:"bookingUUID"`
+	Date         string `json:"date"`
+	EndDate      string `json:"endDate,omitempty"`
+	LocationName string `json:"locationName,omitempty"`
+	Cancelled    bool   `json:"cancelled"`
+	Error        string `json:"error,omitempty"`
+}
+
+func newCancelResult(b *wework.Booking) cancelResult {
+	res := cancelResult{BookingUUID: b.UUID}
+	if days := b.Days(); len(days) > 0 {
+		res.Date = days[0].Format("2006-01-02")
+		if len(days) > 1 {
+			res.EndDate = days[len(days)-1].Format("2006-01-02")
+		}
+	}
+	if b.Reservable != nil && b.Reservable.Location != nil {
+		res.LocationName = b.Reservable.Location.Name
+	}
+	return res
+}
+
+func (r cancelResult) dates() string {
+	if r.EndDate != "" {
+		return fmt.Sprintf("%s ~ %s (multi-day)", r.Date, r.EndDate)
+	}
+	return r.Date
+}
+
+// selectBookings returns the bookings with one of uuids or on one of dates, each once, so a
+// multi-day booking matched by several of its days is cancelled as a whole.
+func selectBookings(upcoming []*wework.Booking, uuids []string, dates []time.Time) ([]*wework.Booking, error) {
+	var selected []*wework.Booking
+	seen := make(map[string]bool)
+	add := func(b *wework.Booking) {
+		if !seen[b.UUID] {
+			seen[b.UUID] = true
+			selected = append(selected, b)
+		}
+	}
+
+	for _, uuid := range uuids {
+		found := false
+		for _, b := range upcoming {
+			if b != nil && b.UUID == uuid {
+				add(b)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no upcoming booking with UUID %s", uuid)
+		}
+	}
+
+	byDate := bookingsByDate(upcoming)
+	for _, d := range dates {
+		for _, b := range byDate[d.Format("2006-01-02")] {
+			add(b)
+		}
+	}
+	return selected, nil
+}
+
+func NewCancelCommand(authenticate func() (*wework.WeWork, error)) *cobra.Command {
+	var bookingUUIDs []string
+	var date string
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "cancel",
+		Short: "Cancel bookings",
+		Long:  `Cancel upcoming bookings by UUID or date. A multi-day booking is cancelled as a whole, whichever of its days is given.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(bookingUUIDs) == 0 && date == "" {
+				return usageError(fmt.Errorf("--booking-uuid or --date is required for cancelling"))
+			}
+
+			var dates []time.Time
+			if date != "" {
+				d, err := tzdate.ParseDates(date, time.Now())
+				if err != nil {
+					return usageError(err)
+				}
+				dates = d
+			}
+
+			ww, err := authenticate()
+			if err != nil {
+				return err
+			}
+
+			jsonOut, _ := cmd.Flags().GetBool("json")
+
+			upcoming, err := loadUpcomingBookings(ww, jsonOut)
+			if err != nil {
+				return err
+			}
+			selected, err := selectBookings(upcoming, bookingUUIDs, dates)
+			if err != nil {
+				return usageError(err)
+			}
+
+			var results []cancelResult
+			for _, b := range selected {
+				results = append(results, newCancelResult(b))
+			}
+
+			if !dryRun {
+				cancel := func(progress progressReporter) {
+					for i, b := range selected {
+						progress.Update(fmt.Sprintf("Cancelling booking %s on %s…", b.UUID, results[i].dates()))
+						if _, err := ww.CancelBooking(b); err != nil {
+							results[i].Error = err.Error()
+						} else {
+							results[i].Cancelled = true
+						}
+					}
+				}
+				if jsonOut || len(selected) == 0 {
+					cancel(quietProgress{})
+				} else {
+					// Failures are recorded per booking in results, so the spinner never returns one
+					_ = spinner.WithContinuousSpinner(func(cs *spinner.ContinuousSpinner) error {
+						cancel(cs)
+						cs.Success(fmt.Sprintf("Processed %d booking(s)", len(selected)))
+						return nil
+					})
+				}
+			}
+
+			var failed int
+			for _, res := range results {
+				if res.Error != "" {
+					failed++
+				}
+			}
+
+			if jsonOut {
+				b, err := json.MarshalIndent(map[string]any{"dryRun": dryRun, "results": results}, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal JSON: %v", err)
+				}
+				fmt.Println(string(b))
+			} else {
+				if len(results) == 0 {
+					fmt.Println("No matching upcoming bookings found.")
+				}
+				for _, res := range results {
+					switch {
+					case dryRun:
+						fmt.Printf("Would cancel %s at %s on %s\n", res.BookingUUID, res.LocationName, res.dates())
+					case res.Cancelled:
+						fmt.Printf("✓ Cancelled %s at %s on %s\n", res.BookingUUID, res.LocationName, res.dates())
+					default:
+						fmt.Printf("❌ %s on %s: %s\n", res.BookingUUID, res.dates(), res.Error)
+					}
+				}
+			}
+
+			switch {
+			case failed == 0:
+				return nil
+			case failed == len(results):
+				return &ExitError{Code: ExitAllFailed, Err: fmt.Errorf("could not cancel %d booking(s)", failed)}
+			default:
+				return &ExitError{Code: ExitPartial, Err: fmt.Errorf("could not cancel %d of %d booking(s)", failed, len(results))}
+			}
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&bookingUUIDs, "booking-uuid", nil, "UUID of the booking to cancel (comma-separated or repeatable)")
+	cmd.Flags().StringVar(&date, "date", "", "Cancel the bookings on these dates: YYYY-MM-DD, a relative date, a comma-separated list or a range")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show which bookings would be cancelled without cancelling them")
+	return cmd
+}
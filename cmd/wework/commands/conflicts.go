@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+// Behaviours accepted by --on-conflict when a requested date already has a booking.
+const (
+	onConflictSkip    = "skip"
+	onConflictFail    = "fail"
+	onConflictReplace = "replace"
+)
+
+// skippedDate is a requested date that was left out, e.g. because a reservation already exists or the location is closed.
+type skippedDate struct {
+	Date         string `json:"date"`
+	Reason       string `json:"reason"`
+	BookingUUID  string `json:"bookingUUID,omitempty"`
+	LocationUUID string `json:"locationUUID,omitempty"`
+	LocationName string `json:"locationName,omitempty"`
+}
+
+func validateOnConflict(onConflict string) error {
+	switch onConflict {
+	case onConflictSkip, onConflictFail, onConflictReplace:
+		return nil
+	}
+	return fmt.Errorf("invalid --on-conflict value %q (expected %s, %s or %s)", onConflict, onConflictSkip, onConflictFail, onConflictReplace)
+}
+
+// bookingsByDate indexes bookings by their calendar days in the booked location's timezone;
+// a multi-day booking is listed under each of its days.
+func bookingsByDate(bookings []*wework.Booking) map[string][]*wework.Booking {
+	byDate := make(map[string][]*wework.Booking)
+	for _, b := range bookings {
+		if b == nil {
+			continue
+		}
+		for _, d := range b.Days() {
+			day := d.Format("2006-01-02")
+			byDate[day] = append(byDate[day], b)
+		}
+	}
+	return byDate
+}
+
+// replacementsFor returns the bookings to replace on days, each once, leaving out bookings that
+// results already replaced; a multi-day booking is listed under each of its days.
+func replacementsFor(existing map[string][]*wework.Booking, days []time.Time, results []bookResult) []*wework.Booking {
+	done := make(map[string]bool)
+	for _, res := range results {
+		for _, uuid := range res.Replaced {
+			done[uuid] = true
+		}
+	}
+	var replace []*wework.Booking
+	for _, d := range days {
+		for _, b := range existing[d.Format("2006-01-02")] {
+			if !done[b.UUID] {
+				done[b.UUID] = true
+				replace = append(replace, b)
+			}
+		}
+	}
+	return replace
+}
+
+// splitConflicts separates dates that already have a booking from those that are free.
+func splitConflicts(dates []time.Time, existing map[string][]*wework.Booking) ([]time.Time, []skippedDate) {
+	var free []time.Time
+	var conflicts []skippedDate
+	for _, d := range dates {
+		dateStr := d.Format("2006-01-02")
+		bookings := existing[dateStr]
+		if len(bookings) == 0 {
+			free = append(free, d)
+			continue
+		}
+		for _, b := range bookings {
+			conflicts = append(conflicts, conflictFor(dateStr, b))
+		}
+	}
+	return free, conflicts
+}
+
+func conflictFor(dateStr string, b *wework.Booking) skippedDate {
+	s := skippedDate{Date: dateStr, Reason: "already booked", BookingUUID: b.UUID}
+	if b.Reservable != nil && b.Reservable.Location != nil {
+		s.LocationUUID = b.Reservable.Location.UUID
+		s.LocationName = b.Reservable.Location.Name
+		s.Reason = fmt.Sprintf("already booked at %s", s.LocationName)
+	}
+	return s
+}
+
+func formatSkipped(skipped []skippedDate) string {
+	var parts []string
+	for _, c := range skipped {
+		parts = append(parts, fmt.Sprintf("%s (%s)", c.Date, c.Reason))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+func TestConflicts(t *testing.T) {
+	at := func(day int) wework.CustomTime {
+		return wework.CustomTime{Time: time.Date(2024, 6, day, 9, 0, 0, 0, time.UTC)}
+	}
+	day := func(d int) time.Time {
+		return time.Date(2024, 6, d, 0, 0, 0, 0, time.UTC)
+	}
+	shibuya := &wework.SharedWorkspace{Location: &wework.SharedWorkspaceLocation{UUID: "loc1", Name: "Shibuya"}}
+	upcoming := []*wework.Booking{
+		{UUID: "single", StartsAt: at(3), Reservable: shibuya},
+		// Monday to Wednesday as one reservation
+		{UUID: "multi", StartsAt: at(10), EndsAt: at(12), IsMultidayBooking: true},
+		{UUID: "second", StartsAt: at(11)},
+		nil,
+	}
+	existing := bookingsByDate(upcoming)
+
+	tests := []struct {
+		name      string
+		dates     []time.Time
+		results   []bookResult
+		free      string
+		conflicts string
+		replace   string
+	}{
+		{
+			name:  "no conflicts",
+			dates: []time.Time{day(4), day(5)},
+			free:  "2024-06-04,2024-06-05",
+		},
+		{
+			name:      "single-day booking",
+			dates:     []time.Time{day(3), day(4)},
+			free:      "2024-06-04",
+			conflicts: "2024-06-03 single already booked at Shibuya",
+			replace:   "single",
+		},
+		{
+			name:      "every day of a multi-day booking conflicts",
+			dates:     []time.Time{day(10), day(12), day(13)},
+			free:      "2024-06-13",
+			conflicts: "2024-06-10 multi already booked,2024-06-12 multi already booked",
+			replace:   "multi",
+		},
+		{
+			name:      "multi-day booking replaced once with another on the same day",
+			dates:     []time.Time{day(10), day(11)},
+			conflicts: "2024-06-10 multi already booked,2024-06-11 multi already booked,2024-06-11 second already booked",
+			replace:   "multi,second",
+		},
+		{
+			name:      "already replaced by an earlier result",
+			dates:     []time.Time{day(11), day(12)},
+			results:   []bookResult{{Date: "2024-06-10", Replaced: []string{"multi"}}},
+			conflicts: "2024-06-11 multi already booked,2024-06-11 second already booked,2024-06-12 multi already booked",
+			replace:   "second",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			free, conflicts := splitConflicts(tt.dates, existing)
+			var gotFree, gotConflicts, gotReplace []string
+			for _, d := range free {
+				gotFree = append(gotFree, d.Format("2006-01-02"))
+			}
+			for _, c := range conflicts {
+				gotConflicts = append(gotConflicts, c.Date+" "+c.BookingUUID+" "+c.Reason)
+			}
+			for _, b := range replacementsFor(existing, tt.dates, tt.results) {
+				gotReplace = append(gotReplace, b.UUID)
+			}
+
+			if got := strings.Join(gotFree, ","); got != tt.free {
+				t.Errorf("expected free %q, got %q", tt.free, got)
+			}
+			if got := strings.Join(gotConflicts, ","); got != tt.conflicts {
+				t.Errorf("expected conflicts %q, got %q", tt.conflicts, got)
+			}
+			if got := strings.Join(gotReplace, ","); got != tt.replace {
+				t.Errorf("expected replacements %q, got %q", tt.replace, got)
+			}
+		})
+	}
+}
+
+func TestValidateOnConflict(t *testing.T) {
+	for _, value := range []string{onConflictSkip, onConflictFail, onConflictReplace} {
+		if err := validateOnConflict(value); err != nil {
+			t.Errorf("validateOnConflict(%q) = %v, want nil", value, err)
+		}
+	}
+	if err := validateOnConflict("overwrite"); err == nil {
+		t.Errorf("validateOnConflict(%q) = nil, want an error", "overwrite")
+	}
+}
@@ -0,0 +1,267 @@
+package commands
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/tzdate"
+	"github.com/dvcrn/wework-cli/pkg/wework"
+	"github.com/spf13/cobra"
+)
+
+// dateOptions holds the date selection flags shared by book, quote and desks.
+type dateOptions struct {
+	Date     string
+	Every    string
+	Interval int
+	Until    string
+	Count    int
+	RRule    string
+	TZ       string
+
+	WeekdaysOnly bool
+	Holidays     []string
+}
+
+func (o *dateOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.Date, "date", "today", "Date in YYYY-MM-DD format or a relative date like tomorrow, next monday, +3d, next week or 2024-W23 (can be a single date, a comma-separated list, or a range like YYYY-MM-DD~YYYY-MM-DD); start date when used with --every or --rrule")
+	cmd.Flags().StringVar(&o.Every, "every", "", "Repeat on these weekdays, e.g. mon,wed,fri or weekdays")
+	cmd.Flags().IntVar(&o.Interval, "interval", 1, "Repeat every N weeks (with --every)")
+	cmd.Flags().StringVar(&o.Until, "until", "", "Last date of the recurrence (YYYY-MM-DD or a relative date like +8w)")
+	cmd.Flags().IntVar(&o.Count, "count", 0, "Number of occurrences of the recurrence")
+	cmd.Flags().StringVar(&o.RRule, "rrule", "", "RFC 5545 recurrence rule, e.g. FREQ=WEEKLY;BYDAY=TU,TH;UNTIL=20240801")
+	cmd.Flags().StringVar(&o.TZ, "tz", "", "Timezone for relative dates like today or tomorrow, e.g. Europe/Berlin or local (default: the location's timezone)")
+	cmd.Flags().BoolVar(&o.WeekdaysOnly, "weekdays-only", false, "Skip Saturdays and Sundays")
+	cmd.Flags().StringSliceVar(&o.Holidays, "holidays", nil, "Holidays to skip: an ICS file, a file with one YYYY-MM-DD per line, or a comma-separated date list (repeatable)")
+}
+
+// resolve expands the date flags into calendar days. Relative dates are interpreted in parseLoc
+// and recurrences are expanded in loc, the location's timezone.
+func (o *dateOptions) resolve(parseLoc, loc *time.Location) ([]time.Time, error) {
+	recurrence, err := o.recurrence(parseLoc)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().In(parseLoc)
+	if recurrence == nil {
+		return tzdate.ParseDates(o.Date, now)
+	}
+
+	start, err := tzdate.ParseDate(o.Date, now)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date for recurrence: %v", err)
+	}
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	return recurrence.Expand(start)
+}
+
+// validate checks the date flags without knowing the location, expanding them in --tz or the
+// local timezone, so invalid dates are reported before logging in.
+func (o *dateOptions) validate() error {
+	_, err := o.resolveIn("")
+	return err
+}
+
+// resolveAt expands the date flags into calendar days at the first of locationUUIDs, in its timezone.
+func (o *dateOptions) resolveAt(ww *wework.WeWork, locationUUIDs []string) ([]time.Time, error) {
+	timezone, err := locationTimeZone(ww, locationUUIDs[0])
+	if err != nil && o.TZ == "" {
+		return nil, fmt.Errorf("%v; pass --tz to choose the timezone", err)
+	}
+	return o.resolveIn(timezone)
+}
+
+// resolveIn expands the date flags into calendar days in the location timezone timezone. Relative
+// dates are interpreted in --tz when set, so "today" means the same day wherever the user is;
+// recurrences are always expanded in the location's timezone.
+func (o *dateOptions) resolveIn(timezone string) ([]time.Time, error) {
+	loc, err := loadTimeZone(timezone)
+	if err != nil {
+		return nil, err
+	}
+	parseLoc := loc
+	if o.TZ != "" {
+		if parseLoc, err = loadTimeZone(o.TZ); err != nil {
+			return nil, err
+		}
+	}
+	if timezone == "" {
+		// Without the location's timezone, --tz is the best guess
+		loc = parseLoc
+	}
+	dates, err := o.resolve(parseLoc, loc)
+	if err != nil || timezone == "" {
+		return dates, err
+	}
+
+	for i, d := range dates {
+		dates[i] = time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc)
+	}
+	return dates, nil
+}
+
+// loadTimeZone loads an IANA timezone, where "" and "local" mean the local timezone.
+func loadTimeZone(name string) (*time.Location, error) {
+	if name == "" || strings.EqualFold(name, "local") {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timezone %s: %v", name, err)
+	}
+	return loc, nil
+}
+
+// locationTimeZone returns the IANA timezone of a location.
+func locationTimeZone(ww *wework.WeWork, locationUUID string) (string, error) {
+	res, err := ww.GetLocationFeatures(locationUUID, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to get timezone of location %s: %v", locationUUID, err)
+	}
+	if len(res.Locations) == 0 || res.Locations[0].TimeZone == "" {
+		return "", fmt.Errorf("no timezone found for location %s", locationUUID)
+	}
+	return res.Locations[0].TimeZone, nil
+}
+
+// formatWindow formats a booking window as "08:30 ~ 20:00 JST".
+func formatWindow(start, end time.Time) string {
+	return fmt.Sprintf("%s ~ %s", start.Format("15:04"), end.Format("15:04 MST"))
+}
+
+// localWindow formats a booking window in the local timezone, or returns "" when the local
+// timezone has the same offset as the location's.
+func localWindow(start, end time.Time) string {
+	_, offset := start.Zone()
+	localStart, localEnd := start.Local(), end.Local()
+	if _, localOffset := localStart.Zone(); localOffset == offset {
+		return ""
+	}
+	window := formatWindow(localStart, localEnd)
+	if localStart.YearDay() != start.YearDay() {
+		window = localStart.Format("Mon ") + window
+	}
+	return window
+}
+
+func (o *dateOptions) recurrence(loc *time.Location) (*tzdate.Recurrence, error) {
+	if o.Every != "" && o.RRule != "" {
+		return nil, fmt.Errorf("--every and --rrule cannot be combined")
+	}
+
+	var r *tzdate.Recurrence
+	switch {
+	case o.RRule != "":
+		parsed, err := tzdate.ParseRRule(o.RRule, loc)
+		if err != nil {
+			return nil, err
+		}
+		r = parsed
+	case o.Every != "":
+		days, err := tzdate.ParseWeekdays(o.Every)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --every: %v", err)
+		}
+		r = &tzdate.Recurrence{Freq: tzdate.Weekly, Interval: o.Interval, ByDay: days}
+	default:
+		if o.Until != "" || o.Count != 0 {
+			return nil, fmt.Errorf("--until and --count require --every or --rrule")
+		}
+		return nil, nil
+	}
+
+	if o.Until != "" {
+		until, err := tzdate.ParseDate(o.Until, time.Now().In(loc))
+		if err != nil {
+			return nil, fmt.Errorf("invalid --until: %v", err)
+		}
+		r.Until = until
+	}
+	if o.Count != 0 {
+		r.Count = o.Count
+	}
+	return r, nil
+}
+
+// exclude drops weekends (with --weekdays-only), holidays and the weekdays in closed from dates.
+func (o *dateOptions) exclude(dates []time.Time, closed map[time.Weekday]bool) ([]time.Time, []skippedDate, error) {
+	holidays := tzdate.Holidays{}
+	for _, spec := range o.Holidays {
+		h, err := tzdate.LoadHolidays(spec)
+		if err != nil {
+			return nil, nil, err
+		}
+		maps.Copy(holidays, h)
+	}
+
+	var kept []time.Time
+	var skipped []skippedDate
+	for _, d := range dates {
+		dateStr := d.Format("2006-01-02")
+		switch {
+		case o.WeekdaysOnly && (d.Weekday() == time.Saturday || d.Weekday() == time.Sunday):
+			skipped = append(skipped, skippedDate{Date: dateStr, Reason: "weekend"})
+		case closed[d.Weekday()]:
+			skipped = append(skipped, skippedDate{Date: dateStr, Reason: fmt.Sprintf("location closed on %s", d.Weekday())})
+		default:
+			if name, ok := holidays.Name(d); ok {
+				skipped = append(skipped, skippedDate{Date: dateStr, Reason: fmt.Sprintf("holiday: %s", name)})
+				continue
+			}
+			kept = append(kept, d)
+		}
+	}
+	return kept, skipped, nil
+}
+
+// closedWeekdays returns the weekdays on which all of the given locations are closed according
+// to their operating hours, so a day is only dropped when no fallback location could be used.
+// Locations without operating hours are left out rather than discarding the others' closed days.
+func closedWeekdays(ww *wework.WeWork, locationUUIDs []string) (map[time.Weekday]bool, error) {
+	var hours [][]wework.OperatingDetail
+	for _, locationUUID := range locationUUIDs {
+		res, err := ww.GetLocationFeatures(locationUUID, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get operating hours: %v", err)
+		}
+		if len(res.Locations) == 0 {
+			continue
+		}
+		hours = append(hours, res.Locations[0].Details.OperatingHours)
+	}
+	return commonClosedWeekdays(hours), nil
+}
+
+// commonClosedWeekdays returns the weekdays without opening hours in every one of the locations'
+// operating hours, or nil when no location has any. Locations without operating hours are skipped.
+func commonClosedWeekdays(hours [][]wework.OperatingDetail) map[time.Weekday]bool {
+	var closed map[time.Weekday]bool
+	for _, locationHours := range hours {
+		if len(locationHours) == 0 {
+			continue
+		}
+		locationClosed := make(map[time.Weekday]bool)
+		for _, h := range locationHours {
+			wd, err := tzdate.ParseWeekday(h.DayOfWeek)
+			if err != nil {
+				continue
+			}
+			if h.TimeOpen == "" {
+				locationClosed[wd] = true
+			}
+		}
+
+		if closed == nil {
+			closed = locationClosed
+			continue
+		}
+		for wd := range closed {
+			if !locationClosed[wd] {
+				delete(closed, wd)
+			}
+		}
+	}
+	return closed
+}
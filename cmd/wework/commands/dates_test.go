@@ -0,0 +1,191 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+func TestResolveIn(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          dateOptions
+		timezone      string
+		expected      []string
+		errorContains string
+	}{
+		{
+			name:     "calendar day in the location timezone",
+			opts:     dateOptions{Date: "2026-03-15"},
+			timezone: "Asia/Tokyo",
+			expected: []string{"2026-03-15 00:00 JST"},
+		},
+		{
+			name:     "tz override keeps the calendar day",
+			opts:     dateOptions{Date: "2026-03-15~2026-03-16", TZ: "America/Los_Angeles"},
+			timezone: "Asia/Tokyo",
+			expected: []string{"2026-03-15 00:00 JST", "2026-03-16 00:00 JST"},
+		},
+		{
+			name:     "rrule expanded in the location timezone",
+			opts:     dateOptions{Date: "2026-03-16", RRule: "FREQ=WEEKLY;BYDAY=TU,TH;UNTIL=20260326"},
+			timezone: "Asia/Tokyo",
+			expected: []string{"2026-03-17 00:00 JST", "2026-03-19 00:00 JST", "2026-03-24 00:00 JST", "2026-03-26 00:00 JST"},
+		},
+		{
+			name:     "every with tz override keeps the location's calendar",
+			opts:     dateOptions{Date: "2026-03-16", Every: "mon,fri", Count: 3, TZ: "America/Los_Angeles"},
+			timezone: "Asia/Tokyo",
+			expected: []string{"2026-03-16 00:00 JST", "2026-03-20 00:00 JST", "2026-03-23 00:00 JST"},
+		},
+		{
+			name:          "unknown tz",
+			opts:          dateOptions{Date: "2026-03-15", TZ: "Mars/Olympus"},
+			timezone:      "Asia/Tokyo",
+			errorContains: "failed to load timezone",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dates, err := tt.opts.resolveIn(tt.timezone)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing '%s', got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []string
+			for _, d := range dates {
+				got = append(got, d.Format("2006-01-02 15:04 MST"))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestExclude(t *testing.T) {
+	holidayFile := filepath.Join(t.TempDir(), "holidays.txt")
+	if err := os.WriteFile(holidayFile, []byte("2026-03-20 Vernal Equinox\n"), 0o644); err != nil {
+		t.Fatalf("failed to write holiday file: %v", err)
+	}
+	// Monday 2026-03-16 through Sunday 2026-03-22
+	var week []time.Time
+	for d := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC); d.Day() <= 22; d = d.AddDate(0, 0, 1) {
+		week = append(week, d)
+	}
+
+	tests := []struct {
+		name          string
+		opts          dateOptions
+		closed        map[time.Weekday]bool
+		expected      string
+		skipped       string
+		errorContains string
+	}{
+		{
+			name:     "nothing excluded",
+			expected: "2026-03-16,2026-03-17,2026-03-18,2026-03-19,2026-03-20,2026-03-21,2026-03-22",
+		},
+		{
+			name:     "weekdays only",
+			opts:     dateOptions{WeekdaysOnly: true},
+			expected: "2026-03-16,2026-03-17,2026-03-18,2026-03-19,2026-03-20",
+			skipped:  "2026-03-21: weekend,2026-03-22: weekend",
+		},
+		{
+			name:     "closed weekdays",
+			closed:   map[time.Weekday]bool{time.Wednesday: true},
+			opts:     dateOptions{WeekdaysOnly: true},
+			expected: "2026-03-16,2026-03-17,2026-03-19,2026-03-20",
+			skipped:  "2026-03-18: location closed on Wednesday,2026-03-21: weekend,2026-03-22: weekend",
+		},
+		{
+			name:     "holiday file and inline list",
+			opts:     dateOptions{WeekdaysOnly: true, Holidays: []string{holidayFile, "2026-03-16,2026-03-17"}},
+			expected: "2026-03-18,2026-03-19",
+			skipped:  "2026-03-16: holiday: holiday,2026-03-17: holiday: holiday,2026-03-20: holiday: Vernal Equinox,2026-03-21: weekend,2026-03-22: weekend",
+		},
+		{
+			name:          "invalid holiday",
+			opts:          dateOptions{Holidays: []string{"next friday"}},
+			errorContains: "invalid holiday date",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, skipped, err := tt.opts.exclude(week, tt.closed)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing '%s', got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var gotKept, gotSkipped []string
+			for _, d := range kept {
+				gotKept = append(gotKept, d.Format("2006-01-02"))
+			}
+			for _, s := range skipped {
+				gotSkipped = append(gotSkipped, s.Date+": "+s.Reason)
+			}
+			if strings.Join(gotKept, ",") != tt.expected {
+				t.Errorf("expected kept %s, got %s", tt.expected, strings.Join(gotKept, ","))
+			}
+			if strings.Join(gotSkipped, ",") != tt.skipped {
+				t.Errorf("expected skipped %s, got %s", tt.skipped, strings.Join(gotSkipped, ","))
+			}
+		})
+	}
+}
+
+func TestCommonClosedWeekdays(t *testing.T) {
+	weekendClosed := []wework.OperatingDetail{
+		{DayOfWeek: "MONDAY", TimeOpen: "08:00", TimeClose: "20:00"},
+		{DayOfWeek: "SATURDAY"},
+		{DayOfWeek: "SUNDAY"},
+	}
+	sundayClosed := []wework.OperatingDetail{
+		{DayOfWeek: "SATURDAY", TimeOpen: "10:00", TimeClose: "16:00"},
+		{DayOfWeek: "SUNDAY"},
+	}
+
+	tests := []struct {
+		name     string
+		hours    [][]wework.OperatingDetail
+		expected []time.Weekday
+	}{
+		{name: "no locations"},
+		{name: "single location", hours: [][]wework.OperatingDetail{weekendClosed}, expected: []time.Weekday{time.Saturday, time.Sunday}},
+		{name: "closed at every location", hours: [][]wework.OperatingDetail{weekendClosed, sundayClosed}, expected: []time.Weekday{time.Sunday}},
+		{name: "open at a fallback", hours: [][]wework.OperatingDetail{weekendClosed, {{DayOfWeek: "SATURDAY", TimeOpen: "10:00"}, {DayOfWeek: "SUNDAY", TimeOpen: "10:00"}}}},
+		{name: "location without operating hours is left out", hours: [][]wework.OperatingDetail{weekendClosed, {}, sundayClosed}, expected: []time.Weekday{time.Sunday}},
+		{name: "no location has operating hours", hours: [][]wework.OperatingDetail{{}, nil}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			closed := commonClosedWeekdays(tt.hours)
+			if len(closed) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, closed)
+			}
+			for _, wd := range tt.expected {
+				if !closed[wd] {
+					t.Errorf("expected %s to be closed, got %v", wd, closed)
+				}
+			}
+		})
+	}
+}
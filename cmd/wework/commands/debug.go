@@ -0,0 +1,16 @@
+package commands
+
+import (
+	"github.com/dvcrn/wework-cli/pkg/wework"
+	"github.com/spf13/cobra"
+)
+
+func NewDebugCommand(authenticate func() (*wework.WeWork, error)) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Diagnostics for troubleshooting bookings",
+		Long:  `Diagnostics that show how the CLI talks to WeWork, for troubleshooting quotes and bookings that fail.`,
+	}
+	cmd.AddCommand(NewDebugStrategyCommand(authenticate))
+	return cmd
+}
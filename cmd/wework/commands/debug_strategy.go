@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dvcrn/wework-cli/pkg/spinner"
+	"github.com/dvcrn/wework-cli/pkg/wework"
+	"github.com/spf13/cobra"
+)
+
+// strategyReport shows which booking strategy a space gets and the IDs it would send.
+type strategyReport struct {
+	SpaceUUID     string                 `json:"spaceUUID"`
+	InventoryUUID string                 `json:"inventoryUUID,omitempty"`
+	KubeID        string                 `json:"kubeId,omitempty"`
+	CwmSpaceID    int                    `json:"cwmSpaceId,omitempty"`
+	LocationName  string                 `json:"locationName"`
+	Key           wework.StrategyKey     `json:"key"`
+	Strategy      string                 `json:"strategy"`
+	Example       string                 `json:"example,omitempty"`
+	Matched       bool                   `json:"matched"`
+	Quote         wework.QuoteParameters `json:"quote"`
+	Booking       wework.QuoteParameters `json:"booking"`
+}
+
+func newStrategyReport(space *wework.Workspace) strategyReport {
+	strategy, matched := wework.StrategyFor(space)
+	r := strategyReport{
+		SpaceUUID:     space.UUID,
+		InventoryUUID: space.InventoryUUID,
+		LocationName:  space.Location.Name,
+		Key:           wework.KeyOf(space),
+		Strategy:      strategy.Name,
+		Example:       strategy.Example,
+		Matched:       matched,
+		Quote:         strategy.QuoteParameters(space),
+		Booking:       strategy.BookingParameters(space),
+	}
+	if space.Reservable != nil {
+		r.KubeID = space.Reservable.KubeId
+		r.CwmSpaceID = space.Reservable.CwmSpaceId
+	}
+	return r
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func NewDebugStrategyCommand(authenticate func() (*wework.WeWork, error)) *cobra.Command {
+	var locationUUID, city, name string
+
+	cmd := &cobra.Command{
+		Use:   "strategy",
+		Short: "Show which booking strategy and IDs a location's spaces use",
+		Long:  `Show which region booking strategy applies to each space of a location, what selects it, and the LocationType and SpaceID that quotes and bookings would send.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if locationUUID == "" && (name == "" || city == "") {
+				return usageError(fmt.Errorf("--location-uuid OR (--city + --name) is required"))
+			}
+
+			ww, err := authenticate()
+			if err != nil {
+				return err
+			}
+
+			jsonOut, _ := cmd.Flags().GetBool("json")
+
+			var spaces []wework.Workspace
+			fetch := func(progress progressReporter) error {
+				progress.Update("Resolving location…")
+				uuid, err := resolveLocationUUID(ww, city, name, locationUUID)
+				if err != nil {
+					return err
+				}
+				progress.Update("Fetching spaces…")
+				res, err := ww.GetSpacesByUUIDs([]string{uuid})
+				if err != nil {
+					return fmt.Errorf("failed to get spaces: %v", err)
+				}
+				spaces = res.Response.Workspaces
+				return nil
+			}
+			if jsonOut {
+				if err := fetch(quietProgress{}); err != nil {
+					return err
+				}
+			} else {
+				if err := spinner.WithContinuousSpinner(func(cs *spinner.ContinuousSpinner) error {
+					if err := fetch(cs); err != nil {
+						return err
+					}
+					cs.Success(fmt.Sprintf("Found %d space(s)", len(spaces)))
+					return nil
+				}); err != nil {
+					return err
+				}
+			}
+
+			var reports []strategyReport
+			for i := range spaces {
+				reports = append(reports, newStrategyReport(&spaces[i]))
+			}
+
+			if jsonOut {
+				b, err := json.MarshalIndent(reports, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal JSON: %v", err)
+				}
+				fmt.Println(string(b))
+				return nil
+			}
+
+			for _, r := range reports {
+				fmt.Printf("\n%s — space %s\n", r.LocationName, r.SpaceUUID)
+				fmt.Printf("  Account type: %d (Kube ID: %s, CWM ID: %s)\n", r.Key.AccountType, yesNo(r.Key.Kube), yesNo(r.Key.CWM))
+				if r.Example != "" {
+					fmt.Printf("  Strategy:     %s (e.g. %s)\n", r.Strategy, r.Example)
+				} else {
+					fmt.Printf("  Strategy:     %s\n", r.Strategy)
+				}
+				fmt.Printf("  Quote:        LocationType %d, SpaceID %s\n", r.Quote.LocationType, r.Quote.SpaceID)
+				fmt.Printf("  Booking:      LocationType %d, SpaceID %s\n", r.Booking.LocationType, r.Booking.SpaceID)
+				if !r.Matched {
+					fmt.Printf("  ⚠️  No strategy handles account type %d; the default may not work here\n", r.Key.AccountType)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&locationUUID, "location-uuid", "", "Location UUID to inspect")
+	cmd.Flags().StringVar(&city, "city", "", "City name")
+	cmd.Flags().StringVar(&name, "name", "", "Space name")
+	return cmd
+}
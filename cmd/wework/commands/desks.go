@@ -7,13 +7,29 @@ import (
 	"time"
 
 	"github.com/dvcrn/wework-cli/pkg/spinner"
-	"github.com/dvcrn/wework-cli/pkg/tzdate"
 	"github.com/dvcrn/wework-cli/pkg/wework"
 	"github.com/spf13/cobra"
 )
 
+// deskResult holds the spaces returned for one requested date.
+type deskResult struct {
+	date time.Time
+	resp *wework.SharedWorkspaceResponse
+}
+
+// resolveDeskDates expands the date flags in the timezone of the looked-up locations, leaving out excluded days.
+func resolveDeskDates(opts *dateOptions, timezone string) ([]time.Time, error) {
+	dates, err := opts.resolveIn(timezone)
+	if err != nil {
+		return nil, err
+	}
+	dates, _, err = opts.exclude(dates, nil)
+	return dates, err
+}
+
 func NewDesksCommand(authenticate func() (*wework.WeWork, error)) *cobra.Command {
-	var locationUUID, city, date string
+	var locationUUID, city string
+	var dateOpts dateOptions
 	cmd := &cobra.Command{
 		Use:   "desks",
 		Short: "List available desks",
@@ -29,7 +45,7 @@ func NewDesksCommand(authenticate func() (*wework.WeWork, error)) *cobra.Command
 
 			jsonOut, _ := cmd.Flags().GetBool("json")
 
-			var resp *wework.SharedWorkspaceResponse
+			var results []deskResult
 			if jsonOut {
 				// Original logic without spinner for JSON mode
 				// Find location UUIDs and timezone
@@ -77,17 +93,19 @@ func NewDesksCommand(authenticate func() (*wework.WeWork, error)) *cobra.Command
 					return fmt.Errorf("could not determine timezone for desks lookup")
 				}
 
-				dateParsed, err := tzdate.ParseInTimezone("2006-01-02", date, "Local")
+				dates, err := resolveDeskDates(&dateOpts, timezone)
 				if err != nil {
 					return err
 				}
 
 				// Get available spaces
-				r, err := ww.GetAvailableSpaces(dateParsed, locationUUIDs)
-				if err != nil {
-					return fmt.Errorf("failed to get available spaces: %v", err)
+				for _, d := range dates {
+					r, err := ww.GetAvailableSpaces(d, locationUUIDs)
+					if err != nil {
+						return fmt.Errorf("failed to get available spaces for %s: %v", d.Format("2006-01-02"), err)
+					}
+					results = append(results, deskResult{date: d, resp: r})
 				}
-				resp = r
 			} else {
 				// Text mode: use spinner to show progress across steps
 				var locationUUIDs []string
@@ -138,17 +156,19 @@ func NewDesksCommand(authenticate func() (*wework.WeWork, error)) *cobra.Command
 						return fmt.Errorf("could not determine timezone for desks lookup")
 					}
 
-					dateParsed, err := tzdate.ParseInTimezone("2006-01-02", date, "Local")
+					dates, err := resolveDeskDates(&dateOpts, timezone)
 					if err != nil {
 						return err
 					}
 
-					cs.Update("Fetching available spaces…")
-					r, err := ww.GetAvailableSpaces(dateParsed, locationUUIDs)
-					if err != nil {
-						return fmt.Errorf("failed to get available spaces: %v", err)
+					for _, d := range dates {
+						cs.Update(fmt.Sprintf("Fetching available spaces for %s…", d.Format("2006-01-02")))
+						r, err := ww.GetAvailableSpaces(d, locationUUIDs)
+						if err != nil {
+							return fmt.Errorf("failed to get available spaces for %s: %v", d.Format("2006-01-02"), err)
+						}
+						results = append(results, deskResult{date: d, resp: r})
 					}
-					resp = r
 					cs.Success("Fetched available spaces")
 					return nil
 				}); err != nil {
@@ -159,19 +179,23 @@ func NewDesksCommand(authenticate func() (*wework.WeWork, error)) *cobra.Command
 			// Output results
 			if jsonOut {
 				type row struct {
+					Date         string `json:"date"`
 					Location     string `json:"location"`
 					ReservableID string `json:"reservableId"`
 					LocationID   string `json:"locationId"`
 					Available    int    `json:"available"`
 				}
-				rows := make([]row, 0, len(resp.Response.Workspaces))
-				for _, space := range resp.Response.Workspaces {
-					rows = append(rows, row{
-						Location:     space.Location.Name,
-						ReservableID: space.UUID,
-						LocationID:   space.Location.UUID,
-						Available:    space.Seat.Available,
-					})
+				rows := []row{}
+				for _, result := range results {
+					for _, space := range result.resp.Response.Workspaces {
+						rows = append(rows, row{
+							Date:         result.date.Format("2006-01-02"),
+							Location:     space.Location.Name,
+							ReservableID: space.UUID,
+							LocationID:   space.Location.UUID,
+							Available:    space.Seat.Available,
+						})
+					}
 				}
 				b, err := json.MarshalIndent(rows, "", "  ")
 				if err != nil {
@@ -179,22 +203,35 @@ func NewDesksCommand(authenticate func() (*wework.WeWork, error)) *cobra.Command
 				}
 				fmt.Println(string(b))
 			} else {
-				if len(resp.Response.Workspaces) == 0 {
-					return fmt.Errorf("no spaces found, or not available for the given date")
-				}
+				found := false
+				for _, result := range results {
+					if len(result.resp.Response.Workspaces) == 0 {
+						if len(results) > 1 {
+							fmt.Printf("\n%s: no spaces available\n", result.date.Format("2006-01-02 Mon"))
+						}
+						continue
+					}
+					found = true
 
-				fmt.Printf("%-30s%-40s%-40s%s\n", "Location", "Reservable ID", "Location ID", "Available")
-				fmt.Println(strings.Repeat("-", 120))
-				for _, space := range resp.Response.Workspaces {
-					name := space.Location.Name
-					if len(name) > 28 {
-						name = name[:28]
-					}
-					fmt.Printf("%-30s%-40s%-40s%d\n",
-						name,
-						space.UUID,
-						space.Location.UUID,
-						space.Seat.Available)
+					if len(results) > 1 {
+						fmt.Printf("\n%s\n", result.date.Format("2006-01-02 Mon"))
+					}
+					fmt.Printf("%-30s%-40s%-40s%s\n", "Location", "Reservable ID", "Location ID", "Available")
+					fmt.Println(strings.Repeat("-", 120))
+					for _, space := range result.resp.Response.Workspaces {
+						name := space.Location.Name
+						if len(name) > 28 {
+							name = name[:28]
+						}
+						fmt.Printf("%-30s%-40s%-40s%d\n",
+							name,
+							space.UUID,
+							space.Location.UUID,
+							space.Seat.Available)
+					}
+				}
+				if !found {
+					return fmt.Errorf("no spaces found, or not available for the given date")
 				}
 			}
 
@@ -203,6 +240,6 @@ func NewDesksCommand(authenticate func() (*wework.WeWork, error)) *cobra.Command
 	}
 	cmd.Flags().StringVar(&locationUUID, "location-uuid", "", "Location UUID")
 	cmd.Flags().StringVar(&city, "city", "", "City name")
-	cmd.Flags().StringVar(&date, "date", time.Now().Format("2006-01-02"), "Date in YYYY-MM-DD format")
+	dateOpts.addFlags(cmd)
 	return cmd
 }
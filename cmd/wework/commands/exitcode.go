@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+// Exit statuses of the wework binary, so scripts can tell failures apart.
+const (
+	ExitOK        = 0
+	ExitFailure   = 1 // unexpected error
+	ExitUsage     = 2 // invalid flags or arguments, nothing was attempted
+	ExitPartial   = 3 // some dates failed, others succeeded
+	ExitAllFailed = 4 // every attempted date failed
+	ExitAuth      = 5 // authentication failed
+)
+
+// ExitError carries the exit status for an error.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the exit status for err.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return ExitFailure
+}
+
+// usageError marks err as a validation error; nil stays nil.
+func usageError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ExitError{Code: ExitUsage, Err: err}
+}
+
+// batchSummary counts the outcomes of a batch of dates.
+type batchSummary struct {
+	Booked     int `json:"booked"`
+	Pending    int `json:"pending"`
+	Unverified int `json:"unverified"`
+	Skipped    int `json:"skipped"`
+	Failed     int `json:"failed"`
+	RolledBack int `json:"rolledBack,omitempty"`
+}
+
+// summarize counts results, where pending and unverified bookings count as booked, and skipped dates.
+func summarize(results []bookResult, skipped []skippedDate) batchSummary {
+	s := batchSummary{Skipped: len(skipped)}
+	for _, res := range results {
+		// A multi-day booking counts once per day, like the dates it stands for
+		n := res.days()
+		switch {
+		case res.Error != "":
+			s.Failed += n
+		case res.Status == wework.BookingPending:
+			s.Booked += n
+			s.Pending += n
+		case res.Status == bookingUnverified:
+			s.Booked += n
+			s.Unverified += n
+		default:
+			s.Booked += n
+		}
+	}
+	return s
+}
+
+// rollBack moves bookings cancelled by an atomic rollback from booked to rolled back.
+func (s *batchSummary) rollBack(rolledBack []rollbackResult) {
+	for _, rb := range rolledBack {
+		if rb.Cancelled {
+			s.Booked--
+			s.RolledBack++
+		}
+	}
+}
+
+func (s batchSummary) String() string {
+	var notes []string
+	if s.Pending > 0 {
+		notes = append(notes, fmt.Sprintf("%d pending approval", s.Pending))
+	}
+	if s.Unverified > 0 {
+		notes = append(notes, fmt.Sprintf("%d unverified", s.Unverified))
+	}
+	parts := []string{fmt.Sprintf("%d booked", s.Booked)}
+	if len(notes) > 0 {
+		parts[0] += fmt.Sprintf(" (%s)", strings.Join(notes, ", "))
+	}
+	parts = append(parts, fmt.Sprintf("%d skipped", s.Skipped), fmt.Sprintf("%d failed", s.Failed))
+	if s.RolledBack > 0 {
+		parts = append(parts, fmt.Sprintf("%d rolled back", s.RolledBack))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// err returns an ExitPartial or ExitAllFailed error when any date failed.
+func (s batchSummary) err() error {
+	switch {
+	case s.Failed == 0:
+		return nil
+	case s.Booked == 0:
+		return &ExitError{Code: ExitAllFailed, Err: fmt.Errorf("all %d attempted date(s) failed", s.Failed)}
+	default:
+		return &ExitError{Code: ExitPartial, Err: fmt.Errorf("%d of %d attempted date(s) failed", s.Failed, s.Failed+s.Booked)}
+	}
+}
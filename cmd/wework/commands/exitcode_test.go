@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dvcrn/wework-cli/pkg/wework"
+	"github.com/spf13/cobra"
+)
+
+func TestBatchSummary(t *testing.T) {
+	confirmed := bookResult{Status: wework.BookingConfirmed}
+	pending := bookResult{Status: wework.BookingPending}
+	failed := bookResult{Status: bookingFailed, Error: "no spaces available"}
+	skipped := []skippedDate{{Date: "2026-03-14", Reason: "weekend"}}
+
+	tests := []struct {
+		name         string
+		results      []bookResult
+		expected     string
+		expectedCode int
+	}{
+		{
+			name:         "all booked",
+			results:      []bookResult{confirmed, pending},
+			expected:     "2 booked (1 pending approval), 1 skipped, 0 failed",
+			expectedCode: ExitOK,
+		},
+		{
+			name:         "unverified booking",
+			results:      []bookResult{confirmed, {Status: bookingUnverified}},
+			expected:     "2 booked (1 unverified), 1 skipped, 0 failed",
+			expectedCode: ExitOK,
+		},
+		{
+			name:         "partial failure",
+			results:      []bookResult{confirmed, failed},
+			expected:     "1 booked, 1 skipped, 1 failed",
+			expectedCode: ExitPartial,
+		},
+		{
+			name:         "total failure",
+			results:      []bookResult{failed, failed},
+			expected:     "0 booked, 1 skipped, 2 failed",
+			expectedCode: ExitAllFailed,
+		},
+		{
+			name:         "nothing to book",
+			expected:     "0 booked, 1 skipped, 0 failed",
+			expectedCode: ExitOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := summarize(tt.results, skipped)
+			if got := summary.String(); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+			if got := ExitCode(summary.err()); got != tt.expectedCode {
+				t.Errorf("expected exit code %d, got %d", tt.expectedCode, got)
+			}
+		})
+	}
+}
+
+func TestSummarizePlans(t *testing.T) {
+	quoted := plannedBooking{Date: "2026-03-16", Credits: 2}
+	failed := plannedBooking{Date: "2026-03-17", Error: "no seats available"}
+	skipped := []skippedDate{{Date: "2026-03-14", Reason: "weekend"}}
+
+	tests := []struct {
+		name         string
+		plans        []plannedBooking
+		expectedCode int
+	}{
+		{name: "all quoted", plans: []plannedBooking{quoted, quoted}, expectedCode: ExitOK},
+		{name: "partial failure", plans: []plannedBooking{quoted, failed}, expectedCode: ExitPartial},
+		{name: "total failure", plans: []plannedBooking{failed, failed}, expectedCode: ExitAllFailed},
+		{name: "nothing to book", expectedCode: ExitOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := summarizePlans(tt.plans, skipped)
+			if summary.Skipped != len(skipped) {
+				t.Errorf("expected %d skipped, got %d", len(skipped), summary.Skipped)
+			}
+			if got := ExitCode(summary.err()); got != tt.expectedCode {
+				t.Errorf("expected exit code %d, got %d", tt.expectedCode, got)
+			}
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if got := ExitCode(fmt.Errorf("wrapped: %w", usageError(fmt.Errorf("bad flag")))); got != ExitUsage {
+		t.Errorf("expected %d for a wrapped usage error, got %d", ExitUsage, got)
+	}
+	if got := ExitCode(fmt.Errorf("boom")); got != ExitFailure {
+		t.Errorf("expected %d for a plain error, got %d", ExitFailure, got)
+	}
+}
+
+func TestValidateBeforeAuthenticate(t *testing.T) {
+	t.Setenv("WEWORK_CONFIG", t.TempDir()+"/config.json")
+	authenticate := func() (*wework.WeWork, error) {
+		t.Fatal("authenticate called before flags were validated")
+		return nil, nil
+	}
+
+	tests := []struct {
+		name string
+		cmd  *cobra.Command
+		args []string
+	}{
+		{name: "book invalid date", cmd: NewBookCommand(authenticate), args: []string{"--location-uuid", "loc", "--date", "not-a-date"}},
+		{name: "book missing location", cmd: NewBookCommand(authenticate), args: []string{"--date", "2026-03-16"}},
+		{name: "book plan with seats", cmd: NewBookCommand(authenticate), args: []string{"--plan", "plan.yaml", "--seats", "2"}},
+		{name: "book plan with location", cmd: NewBookCommand(authenticate), args: []string{"--plan", "plan.yaml", "--location-uuid", "loc"}},
+		{name: "quote invalid date", cmd: NewQuoteCommand(authenticate), args: []string{"--location-uuid", "loc", "--date", "not-a-date"}},
+		{name: "quote missing location", cmd: NewQuoteCommand(authenticate), args: []string{"--date", "2026-03-16"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cmd.Flags().Bool("json", true, "")
+			tt.cmd.SetArgs(tt.args)
+			tt.cmd.SilenceUsage, tt.cmd.SilenceErrors = true, true
+			err := tt.cmd.Execute()
+			if code := ExitCode(err); code != ExitUsage {
+				t.Errorf("expected exit code %d, got %d (%v)", ExitUsage, code, err)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package commands
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/dvcrn/wework-cli/pkg/config"
 	"github.com/dvcrn/wework-cli/pkg/wework"
 )
 
@@ -41,3 +43,26 @@ func resolveLocationUUID(ww *wework.WeWork, city, name, locationUUID string) (st
 
 	return FindLocationByFuzzyName(name, allLocations)
 }
+
+// resolveLocationUUIDs retrieves an ordered list of location UUIDs.
+// locationUUID may be a comma-separated list mixing UUIDs and named lists from the config;
+// without it, a single location is searched based on city and name.
+func resolveLocationUUIDs(ww *wework.WeWork, cfg *config.Config, city, name, locationUUID string) ([]string, error) {
+	if locationUUID == "" {
+		uuid, err := resolveLocationUUID(ww, city, name, locationUUID)
+		if err != nil {
+			return nil, err
+		}
+		return []string{uuid}, nil
+	}
+
+	var refs []string
+	for _, ref := range strings.Split(locationUUID, ",") {
+		refs = append(refs, strings.TrimSpace(ref))
+	}
+	uuids := cfg.ExpandLocations(refs)
+	if len(uuids) == 0 {
+		return nil, fmt.Errorf("no locations given in --location-uuid")
+	}
+	return uuids, nil
+}
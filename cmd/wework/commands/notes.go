@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"strings"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/config"
+	"github.com/dvcrn/wework-cli/pkg/wework"
+	"github.com/spf13/cobra"
+)
+
+// loadProfile returns the config profile selected with the global --profile flag, or the default one.
+func loadProfile(cmd *cobra.Command, cfg *config.Config) (config.Profile, error) {
+	name, _ := cmd.Flags().GetString("profile")
+	return cfg.Profile(name)
+}
+
+// noteTemplate picks the --note value over the profile's default note, with the profile's
+// variables already filled in.
+func noteTemplate(note string, profile config.Profile) string {
+	if note == "" {
+		note = profile.Note
+	}
+	return expandPlaceholders(note, profile.Vars)
+}
+
+// renderNote fills in the placeholders describing a single booking.
+func renderNote(tmpl string, date time.Time, space *wework.Workspace) string {
+	if tmpl == "" {
+		return ""
+	}
+	return expandPlaceholders(tmpl, map[string]string{
+		"date":     date.Format("2006-01-02"),
+		"weekday":  date.Weekday().String(),
+		"location": space.Location.Name,
+		"city":     space.Location.Address.City,
+	})
+}
+
+// expandPlaceholders replaces every {key} in s with its value. Unknown placeholders are left as is.
+func expandPlaceholders(s string, vars map[string]string) string {
+	if len(vars) == 0 || !strings.Contains(s, "{") {
+		return s
+	}
+	var pairs []string
+	for k, v := range vars {
+		pairs = append(pairs, "{"+k+"}", v)
+	}
+	return strings.NewReplacer(pairs...).Replace(s)
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/config"
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+func TestNoteTemplate(t *testing.T) {
+	space := &wework.Workspace{Location: wework.Location{Name: "Shibuya Scramble Square", Address: wework.Address{City: "Tokyo"}}}
+	date := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	profile := config.Profile{Note: "{project}: {location} on {weekday}", Vars: map[string]string{"project": "ACME-42"}}
+
+	tests := []struct {
+		name     string
+		note     string
+		profile  config.Profile
+		expected string
+	}{
+		{
+			name:     "no note",
+			expected: "",
+		},
+		{
+			name:     "profile default",
+			profile:  profile,
+			expected: "ACME-42: Shibuya Scramble Square on Monday",
+		},
+		{
+			name:     "flag overrides profile note but keeps its vars",
+			note:     "{project} {date} {city}",
+			profile:  profile,
+			expected: "ACME-42 2024-06-03 Tokyo",
+		},
+		{
+			name:     "unknown placeholder kept",
+			note:     "{unknown} at {location}",
+			expected: "{unknown} at Shibuya Scramble Square",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := renderNote(noteTemplate(tt.note, tt.profile), date, space)
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
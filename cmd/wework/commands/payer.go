@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/dvcrn/wework-cli/pkg/spinner"
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+// selectPayer resolves --company and --membership against the companies and memberships in the
+// member's profile. Neither given returns the zero Payer, leaving the choice to the server.
+func selectPayer(ww *wework.WeWork, jsonOut bool, company, membership string) (wework.Payer, error) {
+	if company == "" && membership == "" {
+		return wework.Payer{}, nil
+	}
+
+	var payer wework.Payer
+	selectFrom := func(progress progressReporter) error {
+		progress.Update("Checking companies and memberships…")
+		bootstrap, err := ww.GetBootstrap()
+		if err != nil {
+			return fmt.Errorf("failed to get profile: %v", err)
+		}
+		if payer, err = bootstrap.SelectPayer(company, membership); err != nil {
+			return usageError(err)
+		}
+		return nil
+	}
+
+	if jsonOut {
+		return payer, selectFrom(quietProgress{})
+	}
+	err := spinner.WithContinuousSpinner(func(cs *spinner.ContinuousSpinner) error {
+		if err := selectFrom(cs); err != nil {
+			return err
+		}
+		cs.Success(fmt.Sprintf("Booking on behalf of %s", payer))
+		return nil
+	})
+	return payer, err
+}
+
+// withPayer returns opts charged to payer.
+func withPayer(opts wework.BookingOptions, payer wework.Payer) wework.BookingOptions {
+	opts.CompanyUUID = payer.CompanyUUID
+	opts.MembershipUUID = payer.MembershipUUID
+	return opts
+}
+
+// loadPayers fetches the member's companies and memberships for looking up who paid for
+// bookings. It returns nil if they can't be fetched, as they are only shown for information.
+func loadPayers(ww *wework.WeWork, jsonOut bool) *wework.AppBootstrapResponse {
+	if jsonOut {
+		bootstrap, _ := ww.GetBootstrap()
+		return bootstrap
+	}
+
+	// A missing bootstrap is reported as nil, so the spinner never returns an error
+	var bootstrap *wework.AppBootstrapResponse
+	_ = spinner.WithContinuousSpinner(func(cs *spinner.ContinuousSpinner) error {
+		cs.Update("Fetching companies and memberships…")
+		b, err := ww.GetBootstrap()
+		if err != nil {
+			cs.Success("Companies and memberships unavailable, not showing who paid")
+			return nil
+		}
+		bootstrap = b
+		cs.Success("Fetched companies and memberships")
+		return nil
+	})
+	return bootstrap
+}
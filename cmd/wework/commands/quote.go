@@ -1,35 +1,129 @@
 package commands
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/dvcrn/wework-cli/pkg/spinner"
-	"github.com/dvcrn/wework-cli/pkg/tzdate"
 	"github.com/dvcrn/wework-cli/pkg/wework"
 	"github.com/spf13/cobra"
 )
 
+// printQuoteBreakdown prints the line items, taxes and adjustments of a quote down to its
+// grand total, and what that comes to in localCurrency.
+func printQuoteBreakdown(quote *wework.QuoteResponse, localCurrency string) {
+	unit := func(m wework.Money) string {
+		if m.Currency == "" {
+			m.Currency = quote.GrandTotal.Currency
+		}
+		return strings.Replace(m.Currency, "com.wework.", "", 1)
+	}
+	row := func(label string, m wework.Money) {
+		if len(label) > 34 {
+			label = label[:34]
+		}
+		fmt.Printf("  %-36s%10.2f %s\n", label, m.Amount, unit(m))
+	}
+
+	fmt.Printf("  %-36s%10s\n", "Item", "Amount")
+	fmt.Println("  " + strings.Repeat("-", 54))
+	for _, item := range quote.LineItems {
+		label := item.Name
+		if label == "" {
+			label = item.Description
+		}
+		if item.Quantity > 1 {
+			label = fmt.Sprintf("%s (%g × %.2f)", label, item.Quantity, item.UnitPrice.Amount)
+		}
+		row(label, item.TotalPrice)
+	}
+	row("Subtotal", quote.SubTotal)
+	for _, tax := range quote.Taxes {
+		label := "Tax"
+		if tax.Name != "" {
+			label += ": " + tax.Name
+		}
+		if tax.Rate > 0 {
+			label += fmt.Sprintf(" (%g%%)", tax.Rate)
+		}
+		row(label, tax.Amount)
+	}
+	for _, adj := range quote.Adjustments {
+		label := "Adjustment"
+		if name := cmp.Or(adj.Name, adj.Description, adj.Type); name != "" {
+			label += ": " + name
+		}
+		row(label, adj.Amount)
+	}
+	fmt.Println("  " + strings.Repeat("-", 54))
+	row("Grand total", quote.GrandTotal)
+	if quote.GrandTotal.CreditRatio > 0 {
+		fmt.Printf("  %-36s%10.2f\n", "Credit ratio", quote.GrandTotal.CreditRatio)
+	}
+	if amount, ok := quote.GrandTotal.Equivalent(); ok && localCurrency != "" {
+		fmt.Printf("  %-36s%10.2f %s\n", "Currency equivalent", amount, localCurrency)
+	}
+	for _, d := range quote.StatusDetails {
+		fmt.Printf("Status: %s\n", cmp.Or(d.Message, d.Code))
+	}
+}
+
+// persistQuote saves a quote for `book --quote`, recording the space's listed price so a
+// later price change can be noticed.
+func persistQuote(date time.Time, space *wework.Workspace, opts wework.BookingOptions, quote *wework.QuoteResponse) (*wework.SavedQuote, string, error) {
+	if quote.UUID == "" {
+		return nil, "", fmt.Errorf("quote has no UUID")
+	}
+	now := time.Now()
+	saved, err := wework.NewSavedQuote(date, space, opts, quote, now)
+	if err != nil {
+		return nil, "", err
+	}
+	saved.ListedCredits = workspaceCredits(space)
+	path, err := saveQuote(saved, now)
+	if err != nil {
+		return nil, "", err
+	}
+	return saved, path, nil
+}
+
 func NewQuoteCommand(authenticate func() (*wework.WeWork, error)) *cobra.Command {
-	var locationUUID, city, name, date string
+	var locationUUID, city, name, spaceUUID, pick string
+	var dateOpts dateOptions
+	var company, membership string
 
 	cmd := &cobra.Command{
 		Use:   "quote",
 		Short: "Get a booking quote for a workspace",
 		Long:  `Get a booking quote for a workspace at a WeWork location without creating a booking. This is useful for testing availability and pricing.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if locationUUID == "" && (name == "" || city == "") {
+				return usageError(fmt.Errorf("--location-uuid OR (--city + --name) is required for quoting"))
+			}
+
+			jsonOut, _ := cmd.Flags().GetBool("json")
+
+			selector, err := newSpaceSelector(spaceUUID, pick, jsonOut)
+			if err != nil {
+				return usageError(err)
+			}
+			if err := dateOpts.validate(); err != nil {
+				return usageError(err)
+			}
+
 			ww, err := authenticate()
 			if err != nil {
 				return err
 			}
 
-			if locationUUID == "" && (name == "" || city == "") {
-				return fmt.Errorf("--location-uuid OR (--city + --name) is required for quoting")
+			payer, err := selectPayer(ww, jsonOut, company, membership)
+			if err != nil {
+				return err
 			}
-
-			jsonOut, _ := cmd.Flags().GetBool("json")
+			opts := withPayer(wework.BookingOptions{}, payer)
 
 			// Find target location UUID
 			var targetLocationUUID string
@@ -58,49 +152,20 @@ func NewQuoteCommand(authenticate func() (*wework.WeWork, error)) *cobra.Command
 				return fmt.Errorf("could not find any space with the name '%s'", name)
 			}
 
-			// Parse dates
-			dates := []time.Time{}
-			if strings.Contains(date, "~") {
-				// Date range
-				parts := strings.Split(date, "~")
-				if len(parts) != 2 {
-					return fmt.Errorf("invalid date range format. Use YYYY-MM-DD~YYYY-MM-DD")
-				}
-				startDate, err := tzdate.ParseInTimezone("2006-01-02", strings.TrimSpace(parts[0]), "Local")
-				if err != nil {
-					return fmt.Errorf("invalid start date: %v", err)
-				}
-				endDate, err := tzdate.ParseInTimezone("2006-01-02", strings.TrimSpace(parts[1]), "Local")
-				if err != nil {
-					return fmt.Errorf("invalid end date: %v", err)
-				}
-				for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
-					dates = append(dates, d)
-				}
-			} else if strings.Contains(date, ",") {
-				// Comma-separated list
-				for _, d := range strings.Split(date, ",") {
-					parsed, err := tzdate.ParseInTimezone("2006-01-02", strings.TrimSpace(d), "Local")
-					if err != nil {
-						return fmt.Errorf("invalid date format: %v", err)
-					}
-					dates = append(dates, parsed)
-				}
-			} else {
-				// Single date
-				parsed, err := tzdate.ParseInTimezone("2006-01-02", strings.TrimSpace(date), "Local")
-				if err != nil {
-					return fmt.Errorf("invalid date format: %v", err)
-				}
-				dates = append(dates, parsed)
+			dates, err := dateOpts.resolveAt(ww, []string{targetLocationUUID})
+			if err != nil {
+				return usageError(err)
 			}
-
-			tz, err := time.LoadLocation("Local")
+			dates, skipped, err := dateOpts.exclude(dates, nil)
 			if err != nil {
-				return fmt.Errorf("failed to load local timezone: %v", err)
+				return usageError(err)
 			}
-			for i, d := range dates {
-				dates[i] = d.In(tz)
+			// Quoted dates count as booked, so summary.err reports failed quotes like failed bookings
+			summary := batchSummary{Skipped: len(skipped)}
+			if !jsonOut {
+				for _, s := range skipped {
+					fmt.Printf("⏭  %s: %s, skipping\n", s.Date, s.Reason)
+				}
 			}
 
 			// Data structure for results (JSON mode only)
@@ -109,7 +174,15 @@ func NewQuoteCommand(authenticate func() (*wework.WeWork, error)) *cobra.Command
 				SpaceUUID    string                `json:"spaceUUID"`
 				LocationUUID string                `json:"locationUUID"`
 				LocationName string                `json:"locationName"`
+				StartTime    string                `json:"startTime,omitempty"`
+				EndTime      string                `json:"endTime,omitempty"`
+				TimeZone     string                `json:"timeZone,omitempty"`
+				LocalTime    string                `json:"localTime,omitempty"`
 				Quote        *wework.QuoteResponse `json:"quote,omitempty"`
+				Raw          json.RawMessage       `json:"raw,omitempty"`
+				SavedQuote   string                `json:"savedQuote,omitempty"`
+				ExpiresAt    string                `json:"expiresAt,omitempty"`
+				SaveError    string                `json:"saveError,omitempty"`
 				Error        string                `json:"error,omitempty"`
 			}
 
@@ -118,31 +191,36 @@ func NewQuoteCommand(authenticate func() (*wework.WeWork, error)) *cobra.Command
 				// Compute results then emit JSON once
 				for _, bookingDate := range dates {
 					row := resultRow{Date: bookingDate.Format("2006-01-02")}
-					spaces, err := ww.GetAvailableSpaces(bookingDate, []string{targetLocationUUID})
+					space, err := findSpace(ww, quietProgress{}, selector, bookingDate, targetLocationUUID)
 					if err != nil {
-						row.Error = fmt.Sprintf("error getting spaces: %v", err)
+						row.Error = err.Error()
+						summary.Failed++
 						results = append(results, row)
 						continue
 					}
-					if len(spaces.Response.Workspaces) == 0 {
-						row.Error = "no spaces found"
-						results = append(results, row)
-						continue
-					}
-					if len(spaces.Response.Workspaces) > 1 {
-						row.Error = "multiple spaces found, please be more specific"
-						results = append(results, row)
-						continue
-					}
-					space := spaces.Response.Workspaces[0]
 					row.SpaceUUID = space.UUID
 					row.LocationUUID = space.Location.UUID
 					row.LocationName = space.Location.Name
-					q, err := ww.GetBookingQuote(bookingDate, &space)
+					if start, end, err := wework.BookingWindow(bookingDate, space); err == nil {
+						row.StartTime = start.Format("15:04")
+						row.EndTime = end.Format("15:04 MST")
+						row.TimeZone = space.Location.TimeZone
+						row.LocalTime = localWindow(start, end)
+					}
+					q, err := ww.GetBookingQuoteWithOptions(bookingDate, space, opts)
 					if err != nil {
 						row.Error = fmt.Sprintf("failed to get booking quote: %v", err)
+						summary.Failed++
 					} else {
+						summary.Booked++
 						row.Quote = q
+						row.Raw = q.Raw
+						if saved, path, err := persistQuote(bookingDate, space, opts, q); err != nil {
+							row.SaveError = err.Error()
+						} else {
+							row.SavedQuote = path
+							row.ExpiresAt = saved.ExpiresAt.Format(time.RFC3339)
+						}
 					}
 					results = append(results, row)
 				}
@@ -156,61 +234,74 @@ func NewQuoteCommand(authenticate func() (*wework.WeWork, error)) *cobra.Command
 				for _, bookingDate := range dates {
 					var resultDate = bookingDate.Format("2006-01-02")
 					var locationName string
+					var hours, localHours string
+					var localCurrency string
 					var quote *wework.QuoteResponse
+					var saved *wework.SavedQuote
+					var savedPath string
+					var saveErr error
 
 					err := spinner.WithContinuousSpinner(func(cs *spinner.ContinuousSpinner) error {
-						cs.Update(fmt.Sprintf("%s: finding available spaces…", resultDate))
-						spaces, err := ww.GetAvailableSpaces(bookingDate, []string{targetLocationUUID})
+						space, err := findSpace(ww, cs, selector, bookingDate, targetLocationUUID)
 						if err != nil {
-							return fmt.Errorf("%s: error getting spaces: %v", resultDate, err)
-						}
-						if len(spaces.Response.Workspaces) == 0 {
-							return fmt.Errorf("%s: no spaces found", resultDate)
+							return fmt.Errorf("%s: %v", resultDate, err)
 						}
-						if len(spaces.Response.Workspaces) > 1 {
-							return fmt.Errorf("%s: multiple spaces found, please be more specific", resultDate)
-						}
-						space := spaces.Response.Workspaces[0]
 						locationName = space.Location.Name
+						localCurrency = space.Location.Currency
+						if start, end, err := wework.BookingWindow(bookingDate, space); err == nil {
+							hours = formatWindow(start, end)
+							localHours = localWindow(start, end)
+						}
 						cs.Update(fmt.Sprintf("%s: fetching quote for %s…", resultDate, locationName))
-						q, err := ww.GetBookingQuote(bookingDate, &space)
+						q, err := ww.GetBookingQuoteWithOptions(bookingDate, space, opts)
 						if err != nil {
 							return fmt.Errorf("%s: failed to get booking quote: %v", resultDate, err)
 						}
 						quote = q
+						saved, savedPath, saveErr = persistQuote(bookingDate, space, opts, q)
 						cs.Success("Quote retrieved")
 						return nil
 					})
 
 					if err != nil {
-						if strings.Contains(err.Error(), "multiple spaces") {
-							fmt.Println("Found multiple spaces. Please specify a more specific name or use --location-uuid.")
-						} else {
-							fmt.Printf("❌ %v\n", err)
-						}
+						fmt.Printf("❌ %v\n", err)
+						summary.Failed++
 						continue
 					}
+					summary.Booked++
 
 					// Print quote summary
 					fmt.Printf("\nQuote for %s on %s:\n", locationName, resultDate)
-					currency := strings.Replace(quote.GrandTotal.Currency, "com.wework.", "", 1)
+					if hours != "" {
+						fmt.Printf("Hours: %s\n", hours)
+					}
+					if localHours != "" {
+						fmt.Printf("Local time: %s\n", localHours)
+					}
 					fmt.Printf("Quote UUID: %s\n", quote.UUID)
-					fmt.Printf("Total Cost: %.2f %s\n", quote.GrandTotal.Amount, currency)
-					if quote.GrandTotal.CreditRatio > 0 {
-						fmt.Printf("Credit Ratio: %.2f\n", quote.GrandTotal.CreditRatio)
+					printQuoteBreakdown(quote, localCurrency)
+					if saveErr != nil {
+						fmt.Printf("⚠️  Quote not saved: %v\n", saveErr)
+					} else {
+						fmt.Printf("Saved: %s (valid until %s)\n", savedPath, saved.ExpiresAt.Local().Format("15:04"))
+						fmt.Printf("Book it with: wework book --quote %s\n", quote.UUID)
 					}
 					fmt.Println()
 				}
 			}
 
-			return nil
+			return summary.err()
 		},
 	}
 
 	cmd.Flags().StringVar(&locationUUID, "location-uuid", "", "Location UUID for quoting")
 	cmd.Flags().StringVar(&city, "city", "", "City name")
 	cmd.Flags().StringVar(&name, "name", "", "Space name")
-	cmd.Flags().StringVar(&date, "date", time.Now().Format("2006-01-02"), "Date in YYYY-MM-DD format (can be a single date, a comma-separated list, or a range like YYYY-MM-DD~YYYY-MM-DD)")
+	dateOpts.addFlags(cmd)
+	cmd.Flags().StringVar(&spaceUUID, "space-uuid", "", "Workspace UUID to quote when a location has multiple spaces")
+	cmd.Flags().StringVar(&pick, "pick", "", "Strategy when multiple spaces are found: first, most-available or cheapest (default: prompt on a TTY)")
+	cmd.Flags().StringVar(&company, "company", "", "Company to quote for, by UUID or name (default: your account's default)")
+	cmd.Flags().StringVar(&membership, "membership", "", "Membership to quote for, by UUID or name (default: the company's preferred membership)")
 
 	return cmd
 }
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/config"
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+// saveQuote writes q to the quotes directory, named by its UUID, and returns the file's path.
+// Expired quotes are removed along the way.
+func saveQuote(q *wework.SavedQuote, now time.Time) (string, error) {
+	dir, err := config.QuotesDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create quotes directory: %v", err)
+	}
+	pruneQuotes(dir, now)
+
+	b, err := json.MarshalIndent(q, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal quote: %v", err)
+	}
+	path := filepath.Join(dir, q.Quote.UUID+".json")
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		return "", fmt.Errorf("failed to save quote: %v", err)
+	}
+	return path, nil
+}
+
+// pruneQuotes removes the expired quotes in dir, ignoring files it can't read.
+func pruneQuotes(dir string, now time.Time) {
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	for _, path := range paths {
+		if q, err := readQuote(path); err == nil && q.Expired(now) {
+			os.Remove(path)
+		}
+	}
+}
+
+// loadQuote reads a saved quote given by file path or quote UUID.
+func loadQuote(ref string) (*wework.SavedQuote, error) {
+	if _, err := os.Stat(ref); err == nil || strings.ContainsRune(ref, os.PathSeparator) {
+		return readQuote(ref)
+	}
+	dir, err := config.QuotesDir()
+	if err != nil {
+		return nil, err
+	}
+	q, err := readQuote(filepath.Join(dir, ref+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no saved quote %s, run `wework quote` first", ref)
+	}
+	return q, err
+}
+
+func readQuote(path string) (*wework.SavedQuote, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var q wework.SavedQuote
+	if err := json.Unmarshal(b, &q); err != nil {
+		return nil, fmt.Errorf("failed to parse quote %s: %v", path, err)
+	}
+	if q.Quote == nil || q.Quote.UUID == "" {
+		return nil, fmt.Errorf("%s is not a saved quote", path)
+	}
+	return &q, nil
+}
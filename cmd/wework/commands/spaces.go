@@ -0,0 +1,289 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/wework"
+	"golang.org/x/term"
+)
+
+// Strategies accepted by --pick when a location returns more than one workspace.
+const (
+	pickFirst         = "first"
+	pickMostAvailable = "most-available"
+	pickCheapest      = "cheapest"
+)
+
+// progressReporter is the subset of *spinner.ContinuousSpinner used while processing a single date.
+type progressReporter interface {
+	Update(message string)
+	Suspend(fn func() error) error
+}
+
+// quietProgress is used in JSON mode, where nothing but the final payload may be written to stdout.
+type quietProgress struct{}
+
+func (quietProgress) Update(string)                 {}
+func (quietProgress) Suspend(fn func() error) error { return fn() }
+
+// spaceSelector decides which workspace to use when a location returns several.
+type spaceSelector struct {
+	SpaceUUID   string
+	Pick        string
+	Interactive bool
+	// Seats is the number of seats the chosen space must have free. Zero means one.
+	Seats int
+}
+
+func newSpaceSelector(spaceUUID, pick string, jsonOut bool) (spaceSelector, error) {
+	switch pick {
+	case "", pickFirst, pickMostAvailable, pickCheapest:
+	default:
+		return spaceSelector{}, fmt.Errorf("invalid --pick value %q (expected %s, %s or %s)", pick, pickFirst, pickMostAvailable, pickCheapest)
+	}
+
+	return spaceSelector{
+		SpaceUUID:   spaceUUID,
+		Pick:        pick,
+		Interactive: !jsonOut && term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd())),
+	}, nil
+}
+
+// spaceLister lists the workspaces of locations on a date; *wework.WeWork implements it.
+type spaceLister interface {
+	GetAvailableSpaces(t time.Time, locationUUIDs []string) (*wework.SharedWorkspaceResponse, error)
+}
+
+// fittingSpaces lists only the workspaces with room for seats, so choosing among them never
+// settles on a full space while another one has seats free.
+type fittingSpaces struct {
+	spaceLister
+	seats int
+}
+
+func (f fittingSpaces) GetAvailableSpaces(t time.Time, locationUUIDs []string) (*wework.SharedWorkspaceResponse, error) {
+	res, err := f.spaceLister.GetAvailableSpaces(t, locationUUIDs)
+	if err != nil {
+		return nil, err
+	}
+	filtered := *res
+	filtered.Response.Workspaces = nil
+	for _, ws := range res.Response.Workspaces {
+		if workspaceFits(&ws, f.seats) {
+			filtered.Response.Workspaces = append(filtered.Response.Workspaces, ws)
+		}
+	}
+	return &filtered, nil
+}
+
+// findSpace fetches the workspaces of a location for the given date and selects one of them.
+func findSpace(ww spaceLister, progress progressReporter, sel spaceSelector, date time.Time, locationUUID string) (*wework.Workspace, error) {
+	dateStr := date.Format("2006-01-02")
+	progress.Update(fmt.Sprintf("%s: finding available spaces…", dateStr))
+
+	spaces, err := ww.GetAvailableSpaces(date, []string{locationUUID})
+	if err != nil {
+		return nil, fmt.Errorf("error getting spaces: %v", err)
+	}
+
+	return sel.choose(progress, dateStr, spaces.Response.Workspaces)
+}
+
+// findSpaceInOrder tries locations in order and returns the first space with a free seat,
+// along with a note for every location that was passed over. The last location is always
+// attempted, so a single location behaves exactly like findSpace.
+func findSpaceInOrder(ww spaceLister, progress progressReporter, sel spaceSelector, date time.Time, locationUUIDs []string) (*wework.Workspace, []string, error) {
+	var passed []string
+	for i, locationUUID := range locationUUIDs {
+		if i == len(locationUUIDs)-1 {
+			space, err := findSpace(ww, progress, sel, date, locationUUID)
+			return space, passed, err
+		}
+
+		dateStr := date.Format("2006-01-02")
+		progress.Update(fmt.Sprintf("%s: checking availability at location %d of %d…", dateStr, i+1, len(locationUUIDs)))
+		spaces, err := ww.GetAvailableSpaces(date, []string{locationUUID})
+		if err != nil {
+			passed = append(passed, fmt.Sprintf("%s: error getting spaces: %v", locationUUID, err))
+			continue
+		}
+
+		var free []wework.Workspace
+		for _, ws := range spaces.Response.Workspaces {
+			if workspaceFits(&ws, sel.seatCount()) {
+				free = append(free, ws)
+			}
+		}
+		if len(free) == 0 {
+			passed = append(passed, fmt.Sprintf("%s: %s", locationName(spaces.Response.Workspaces, locationUUID), noSeatsReason(sel.seatCount())))
+			continue
+		}
+
+		space, err := sel.choose(progress, dateStr, free)
+		if err != nil {
+			passed = append(passed, fmt.Sprintf("%s: %v", locationName(free, locationUUID), err))
+			continue
+		}
+		return space, passed, nil
+	}
+	return nil, passed, fmt.Errorf("no locations given")
+}
+
+// locationName returns the name of the location the workspaces belong to, or fallback if unknown.
+func locationName(workspaces []wework.Workspace, fallback string) string {
+	if len(workspaces) > 0 && workspaces[0].Location.Name != "" {
+		return workspaces[0].Location.Name
+	}
+	return fallback
+}
+
+func (s spaceSelector) choose(progress progressReporter, dateStr string, workspaces []wework.Workspace) (*wework.Workspace, error) {
+	if len(workspaces) == 0 {
+		return nil, noSeatsError("no spaces found")
+	}
+
+	if s.SpaceUUID != "" {
+		for i := range workspaces {
+			if workspaces[i].UUID == s.SpaceUUID || workspaces[i].InventoryUUID == s.SpaceUUID {
+				if s.Seats > 1 && !workspaceFits(&workspaces[i], s.Seats) {
+					return nil, fmt.Errorf("space %s has only %d seats available, %d requested", s.SpaceUUID, workspaces[i].Seat.Available, s.Seats)
+				}
+				return &workspaces[i], nil
+			}
+		}
+		return nil, fmt.Errorf("space %s not found among %d available spaces", s.SpaceUUID, len(workspaces))
+	}
+
+	// Only filter for group bookings, so a single seat keeps the API's own error for full spaces
+	if s.Seats > 1 {
+		var fitting []wework.Workspace
+		for _, ws := range workspaces {
+			if workspaceFits(&ws, s.Seats) {
+				fitting = append(fitting, ws)
+			}
+		}
+		if len(fitting) == 0 {
+			return nil, noSeatsError(noSeatsReason(s.Seats))
+		}
+		workspaces = fitting
+	}
+
+	if len(workspaces) == 1 {
+		return &workspaces[0], nil
+	}
+
+	switch s.Pick {
+	case pickFirst:
+		return &workspaces[0], nil
+	case pickMostAvailable:
+		best := 0
+		for i := range workspaces {
+			if workspaces[i].Seat.Available > workspaces[best].Seat.Available {
+				best = i
+			}
+		}
+		return &workspaces[best], nil
+	case pickCheapest:
+		best := 0
+		for i := range workspaces {
+			if workspaceCredits(&workspaces[i]) < workspaceCredits(&workspaces[best]) {
+				best = i
+			}
+		}
+		return &workspaces[best], nil
+	}
+
+	if !s.Interactive {
+		var names []string
+		for _, ws := range workspaces {
+			names = append(names, fmt.Sprintf("%s (%s)", ws.Location.Name, ws.UUID))
+		}
+		return nil, fmt.Errorf("multiple spaces found: %s; use --space-uuid or --pick", strings.Join(names, ", "))
+	}
+
+	var picked *wework.Workspace
+	err := progress.Suspend(func() error {
+		ws, err := promptForSpace(dateStr, workspaces)
+		picked = ws
+		return err
+	})
+	return picked, err
+}
+
+func (s spaceSelector) seatCount() int {
+	if s.Seats < 1 {
+		return 1
+	}
+	return s.Seats
+}
+
+// workspaceFits reports whether a workspace has seats free and capacity for the given number of people.
+func workspaceFits(ws *wework.Workspace, seats int) bool {
+	if ws.Seat.Available < seats {
+		return false
+	}
+	if ws.Reservable != nil && ws.Reservable.Capacity > 0 && ws.Reservable.Capacity < seats {
+		return false
+	}
+	return true
+}
+
+// noSeatsError reports that a location has no space with enough free seats, as opposed to
+// failing to look.
+type noSeatsError string
+
+func (e noSeatsError) Error() string {
+	return string(e)
+}
+
+// isNoSeats reports whether err only means that no seats are available.
+func isNoSeats(err error) bool {
+	var noSeats noSeatsError
+	return errors.As(err, &noSeats)
+}
+
+func noSeatsReason(seats int) string {
+	if seats > 1 {
+		return fmt.Sprintf("no space with %d seats available", seats)
+	}
+	return "no seats available"
+}
+
+// workspaceCredits returns the credit price of a workspace, falling back to the product price.
+func workspaceCredits(ws *wework.Workspace) float64 {
+	if ws.Credits > 0 {
+		return float64(ws.Credits)
+	}
+	if ws.ProductPrice != nil {
+		return ws.ProductPrice.Price.Amount
+	}
+	return 0
+}
+
+func promptForSpace(dateStr string, workspaces []wework.Workspace) (*wework.Workspace, error) {
+	fmt.Printf("\nMultiple spaces found for %s:\n", dateStr)
+	for i, ws := range workspaces {
+		fmt.Printf("  %d) %s — %d seats available, %.0f credits (%s)\n",
+			i+1, ws.Location.Name, ws.Seat.Available, workspaceCredits(&ws), ws.UUID)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Printf("Select a space [1-%d]: ", len(workspaces))
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("no space selected: %v", err)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(line))
+		if err == nil && n >= 1 && n <= len(workspaces) {
+			return &workspaces[n-1], nil
+		}
+		fmt.Println("Invalid selection.")
+	}
+}
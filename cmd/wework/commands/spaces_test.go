@@ -0,0 +1,233 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+func TestSpaceSelectorChoose(t *testing.T) {
+	workspaces := []wework.Workspace{
+		{UUID: "space1", InventoryUUID: "inv1", Credits: 3, Seat: wework.Seat{Available: 2}},
+		{UUID: "space2", InventoryUUID: "inv2", Credits: 1, Seat: wework.Seat{Available: 5}},
+		{UUID: "space3", InventoryUUID: "inv3", Credits: 2, Seat: wework.Seat{Available: 9}},
+	}
+
+	tests := []struct {
+		name          string
+		selector      spaceSelector
+		workspaces    []wework.Workspace
+		expectedUUID  string
+		errorContains string
+	}{
+		{
+			name:         "single space",
+			selector:     spaceSelector{},
+			workspaces:   workspaces[:1],
+			expectedUUID: "space1",
+		},
+		{
+			name:         "explicit space uuid",
+			selector:     spaceSelector{SpaceUUID: "space2"},
+			workspaces:   workspaces,
+			expectedUUID: "space2",
+		},
+		{
+			name:         "explicit inventory uuid",
+			selector:     spaceSelector{SpaceUUID: "inv3"},
+			workspaces:   workspaces,
+			expectedUUID: "space3",
+		},
+		{
+			name:          "unknown space uuid",
+			selector:      spaceSelector{SpaceUUID: "nope"},
+			workspaces:    workspaces,
+			errorContains: "not found",
+		},
+		{
+			name:         "pick first",
+			selector:     spaceSelector{Pick: pickFirst},
+			workspaces:   workspaces,
+			expectedUUID: "space1",
+		},
+		{
+			name:         "pick most available",
+			selector:     spaceSelector{Pick: pickMostAvailable},
+			workspaces:   workspaces,
+			expectedUUID: "space3",
+		},
+		{
+			name:         "pick cheapest",
+			selector:     spaceSelector{Pick: pickCheapest},
+			workspaces:   workspaces,
+			expectedUUID: "space2",
+		},
+		{
+			name:          "multiple without strategy",
+			selector:      spaceSelector{},
+			workspaces:    workspaces,
+			errorContains: "multiple spaces found",
+		},
+		{
+			name:         "seats filter out small spaces",
+			selector:     spaceSelector{Pick: pickFirst, Seats: 4},
+			workspaces:   workspaces,
+			expectedUUID: "space2",
+		},
+		{
+			name:          "seats exceed every space",
+			selector:      spaceSelector{Pick: pickFirst, Seats: 10},
+			workspaces:    workspaces,
+			errorContains: "no space with 10 seats",
+		},
+		{
+			name:          "explicit space too small",
+			selector:      spaceSelector{SpaceUUID: "space1", Seats: 3},
+			workspaces:    workspaces,
+			errorContains: "only 2 seats available",
+		},
+		{
+			name:          "no spaces",
+			selector:      spaceSelector{Pick: pickFirst},
+			workspaces:    nil,
+			errorContains: "no spaces found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			space, err := tt.selector.choose(quietProgress{}, "2024-06-01", tt.workspaces)
+			if tt.errorContains != "" {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				if !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("expected error to contain '%s', got '%s'", tt.errorContains, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if space.UUID != tt.expectedUUID {
+				t.Errorf("expected space %s, got %s", tt.expectedUUID, space.UUID)
+			}
+		})
+	}
+}
+
+// fakeSpaces serves workspaces per location UUID, failing for locations in errs.
+type fakeSpaces struct {
+	spaces map[string][]wework.Workspace
+	errs   map[string]error
+}
+
+func (f fakeSpaces) GetAvailableSpaces(_ time.Time, locationUUIDs []string) (*wework.SharedWorkspaceResponse, error) {
+	if err := f.errs[locationUUIDs[0]]; err != nil {
+		return nil, err
+	}
+	res := &wework.SharedWorkspaceResponse{}
+	res.Response.Workspaces = f.spaces[locationUUIDs[0]]
+	return res, nil
+}
+
+func TestFindSpaceInOrder(t *testing.T) {
+	workspace := func(uuid, location string, available int) wework.Workspace {
+		return wework.Workspace{UUID: uuid, Seat: wework.Seat{Available: available}, Location: wework.Location{UUID: location, Name: location}}
+	}
+	lister := fakeSpaces{
+		spaces: map[string][]wework.Workspace{
+			"full":   {workspace("full-space", "full", 0)},
+			"open":   {workspace("open-space", "open", 4)},
+			"single": {workspace("single-space", "single", 1)},
+		},
+		errs: map[string]error{"broken": fmt.Errorf("unauthorized")},
+	}
+
+	tests := []struct {
+		name          string
+		locations     []string
+		seats         int
+		expectedUUID  string
+		passed        string
+		errorContains string
+	}{
+		{name: "first location free", locations: []string{"open", "full"}, expectedUUID: "open-space"},
+		{name: "falls back when the first is full", locations: []string{"full", "open"}, expectedUUID: "open-space", passed: "full: no seats available"},
+		{name: "falls back past an error", locations: []string{"broken", "open"}, expectedUUID: "open-space", passed: "broken: error getting spaces: unauthorized"},
+		{name: "falls back for a group", locations: []string{"single", "open"}, seats: 3, expectedUUID: "open-space", passed: "single: no space with 3 seats available"},
+		{name: "last location is always attempted", locations: []string{"open-missing", "full"}, expectedUUID: "full-space", passed: "open-missing: no seats available"},
+		{name: "error at the last location", locations: []string{"full", "broken"}, passed: "full: no seats available", errorContains: "unauthorized"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel := spaceSelector{Pick: pickFirst, Seats: tt.seats}
+			space, passed, err := findSpaceInOrder(lister, quietProgress{}, sel, time.Now(), tt.locations)
+			if got := strings.Join(passed, "|"); got != tt.passed {
+				t.Errorf("expected passed %q, got %q", tt.passed, got)
+			}
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing '%s', got %v", tt.errorContains, err)
+				}
+				if isNoSeats(err) {
+					t.Errorf("expected %v not to count as no seats", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if space.UUID != tt.expectedUUID {
+				t.Errorf("expected space %s, got %s", tt.expectedUUID, space.UUID)
+			}
+		})
+	}
+}
+
+func TestFittingSpaces(t *testing.T) {
+	workspace := func(uuid string, available int) wework.Workspace {
+		return wework.Workspace{UUID: uuid, Seat: wework.Seat{Available: available}, Location: wework.Location{UUID: "loc", Name: "loc"}}
+	}
+	lister := fittingSpaces{
+		spaceLister: fakeSpaces{spaces: map[string][]wework.Workspace{
+			"loc":  {workspace("full-space", 0), workspace("open-space", 2)},
+			"full": {workspace("full-space", 0)},
+		}},
+		seats: 1,
+	}
+
+	tests := []struct {
+		name         string
+		pick         string
+		location     string
+		expectedUUID string
+		noSeats      bool
+	}{
+		{name: "first skips a full space", pick: pickFirst, location: "loc", expectedUUID: "open-space"},
+		{name: "cheapest skips a full space", pick: pickCheapest, location: "loc", expectedUUID: "open-space"},
+		{name: "only full spaces", pick: pickFirst, location: "full", noSeats: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			space, _, err := findSpaceInOrder(lister, quietProgress{}, spaceSelector{Pick: tt.pick}, time.Now(), []string{tt.location})
+			if tt.noSeats {
+				if !isNoSeats(err) {
+					t.Fatalf("expected no seats, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if space.UUID != tt.expectedUUID {
+				t.Errorf("expected space %s, got %s", tt.expectedUUID, space.UUID)
+			}
+		})
+	}
+}
@@ -0,0 +1,352 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/config"
+	"github.com/dvcrn/wework-cli/pkg/wework"
+	"github.com/spf13/cobra"
+)
+
+// watchEvent is emitted whenever the watcher finds, books or gives up on a date.
+type watchEvent struct {
+	Time          string                  `json:"time"`
+	Event         string                  `json:"event"`
+	Date          string                  `json:"date,omitempty"`
+	LocationUUID  string                  `json:"locationUUID,omitempty"`
+	LocationName  string                  `json:"locationName,omitempty"`
+	SpaceUUID     string                  `json:"spaceUUID,omitempty"`
+	Available     int                     `json:"available,omitempty"`
+	BookingStatus *wework.BookingResponse `json:"booking,omitempty"`
+	Message       string                  `json:"message,omitempty"`
+}
+
+// watchNotifier delivers watch events to stdout and the configured hooks.
+type watchNotifier struct {
+	jsonOut bool
+	webhook string
+	execCmd string
+}
+
+func (n watchNotifier) notify(ev watchEvent) {
+	ev.Time = time.Now().Format(time.RFC3339)
+
+	if n.jsonOut {
+		b, _ := json.Marshal(ev)
+		fmt.Println(string(b))
+	} else {
+		line := fmt.Sprintf("[%s] %s", time.Now().Format("15:04:05"), ev.Event)
+		if ev.Date != "" {
+			line += " " + ev.Date
+		}
+		if ev.LocationName != "" {
+			line += fmt.Sprintf(" at %s", ev.LocationName)
+		}
+		if ev.Available > 0 {
+			line += fmt.Sprintf(" (%d seats available)", ev.Available)
+		}
+		if ev.Message != "" {
+			line += ": " + ev.Message
+		}
+		fmt.Println(line)
+	}
+
+	// Hooks are only interesting for outcomes, not for progress
+	if ev.Event != "available" && ev.Event != "booked" {
+		return
+	}
+
+	if n.webhook != "" {
+		if err := postWebhook(n.webhook, ev); err != nil {
+			fmt.Fprintf(os.Stderr, "webhook failed: %v\n", err)
+		}
+	}
+	if n.execCmd != "" {
+		c := exec.Command("sh", "-c", n.execCmd)
+		c.Stdout = os.Stderr
+		c.Stderr = os.Stderr
+		c.Env = append(os.Environ(),
+			"WEWORK_EVENT="+ev.Event,
+			"WEWORK_DATE="+ev.Date,
+			"WEWORK_LOCATION_UUID="+ev.LocationUUID,
+			"WEWORK_LOCATION_NAME="+ev.LocationName,
+			"WEWORK_SPACE_UUID="+ev.SpaceUUID,
+			"WEWORK_SEATS_AVAILABLE="+strconv.Itoa(ev.Available),
+		)
+		if err := c.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "exec hook failed: %v\n", err)
+		}
+	}
+}
+
+func postWebhook(url string, ev watchEvent) error {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// watchMaxFailedPolls is how many polls in a row may fail to check any date before watch gives
+// up, so a persistent error like an expired login doesn't poll forever.
+const watchMaxFailedPolls = 5
+
+// parseDeadline accepts a duration from now ("6h") or a local timestamp ("2024-06-01 09:00" or RFC 3339).
+func parseDeadline(s string) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02 15:04", s, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --deadline '%s' (expected a duration like 6h or a time like 2006-01-02 15:04)", s)
+}
+
+// watcher polls for free seats on dates until each one is reported or booked.
+type watcher struct {
+	notify func(watchEvent)
+	// find returns a space with a free seat on date; an error for which isNoSeats holds means there is none yet
+	find func(date time.Time) (*wework.Workspace, error)
+	// book books space on date; if nil, free seats are only reported
+	book func(date time.Time, space *wework.Workspace) bookResult
+	now  func() time.Time
+	// sleep waits for the given duration, returning an error if ctx is cancelled first
+	sleep func(ctx context.Context, d time.Duration) error
+
+	pollInterval time.Duration
+	jitter       time.Duration
+	until        time.Time
+	jsonOut      bool
+}
+
+// run watches dates until every date is reported or booked, the deadline passes, polls keep
+// failing or ctx is cancelled.
+func (w watcher) run(ctx context.Context, dates []time.Time) error {
+	pending := dates
+	failedPolls := 0
+	for len(pending) > 0 {
+		var remaining []time.Time
+		var checked, failed int
+		for _, date := range pending {
+			dateStr := date.Format("2006-01-02")
+			if w.now().After(date.AddDate(0, 0, 1)) {
+				w.notify(watchEvent{Event: "expired", Date: dateStr, Message: "date has passed"})
+				continue
+			}
+
+			space, err := w.find(date)
+			if err != nil && !isNoSeats(err) {
+				// Not about availability (auth, a bad location, the API being down): report it
+				failed++
+				w.notify(watchEvent{Event: "error", Date: dateStr, Message: err.Error()})
+				remaining = append(remaining, date)
+				continue
+			}
+			checked++
+			if err != nil || space.Seat.Available <= 0 {
+				remaining = append(remaining, date)
+				continue
+			}
+
+			ev := watchEvent{
+				Event:        "available",
+				Date:         dateStr,
+				LocationUUID: space.Location.UUID,
+				LocationName: space.Location.Name,
+				SpaceUUID:    space.UUID,
+				Available:    space.Seat.Available,
+			}
+			if w.book == nil {
+				// Nothing secures the seat, so the date is reported once and no longer watched
+				w.notify(ev)
+				continue
+			}
+
+			res := w.book(date, space)
+			ev.BookingStatus = res.BookingStatus
+			if res.Error != "" {
+				ev.Event = "failed"
+				ev.Message = res.Error
+				w.notify(ev)
+				remaining = append(remaining, date)
+				continue
+			}
+			ev.Event = "booked"
+			ev.Message = fmt.Sprintf("reservation %s", res.BookingStatus.ReservationID)
+			w.notify(ev)
+		}
+
+		pending = remaining
+		if len(pending) == 0 {
+			break
+		}
+		if failed > 0 && checked == 0 {
+			failedPolls++
+		} else {
+			failedPolls = 0
+		}
+		if failedPolls >= watchMaxFailedPolls {
+			return fmt.Errorf("giving up after %d polls in a row failed to check availability", failedPolls)
+		}
+
+		wait := w.pollInterval
+		if w.jitter > 0 {
+			wait += time.Duration(rand.Int63n(int64(w.jitter)))
+		}
+		if !w.until.IsZero() && w.now().Add(wait).After(w.until) {
+			for _, date := range pending {
+				w.notify(watchEvent{Event: "deadline", Date: date.Format("2006-01-02"), Message: "no seat found before the deadline"})
+			}
+			return fmt.Errorf("deadline reached with %d date(s) still unsecured", len(pending))
+		}
+		if !w.jsonOut {
+			fmt.Printf("[%s] waiting %s for %d date(s)…\n", w.now().Format("15:04:05"), wait.Round(time.Second), len(pending))
+		}
+
+		if err := w.sleep(ctx, wait); err != nil {
+			return fmt.Errorf("watch interrupted with %d date(s) still unsecured", len(pending))
+		}
+	}
+	return nil
+}
+
+func NewWatchCommand(authenticate func() (*wework.WeWork, error)) *cobra.Command {
+	var locationUUID, city, name, spaceUUID, pick string
+	var dateOpts dateOptions
+	var pollInterval, jitter time.Duration
+	var deadline, webhook, execCmd string
+	var book bool
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Wait for a free seat and notify or book",
+		Long:  `Poll a location for free seats on the given dates. When a seat becomes available, notify (stdout, webhook or a command) or book it right away with --book. Without --book, each date is reported once when a seat frees up and is then no longer watched, since nothing holds the seat. Stops once every date is booked or reported, the deadline passes, or availability can't be checked for several polls in a row.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ww, err := authenticate()
+			if err != nil {
+				return err
+			}
+
+			if locationUUID == "" && (name == "" || city == "") {
+				return fmt.Errorf("--location-uuid OR (--city + --name) is required for watching")
+			}
+			if pollInterval <= 0 {
+				return fmt.Errorf("--poll-interval must be positive")
+			}
+
+			jsonOut, _ := cmd.Flags().GetBool("json")
+			notifier := watchNotifier{jsonOut: jsonOut, webhook: webhook, execCmd: execCmd}
+
+			// Watching runs unattended, so never prompt for a space
+			selector, err := newSpaceSelector(spaceUUID, pick, jsonOut)
+			if err != nil {
+				return err
+			}
+			selector.Interactive = false
+
+			var until time.Time
+			if deadline != "" {
+				until, err = parseDeadline(deadline)
+				if err != nil {
+					return err
+				}
+			}
+
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			targetLocationUUIDs, err := resolveLocationUUIDs(ww, cfg, city, name, locationUUID)
+			if err != nil {
+				return err
+			}
+
+			dates, err := dateOpts.resolveAt(ww, targetLocationUUIDs)
+			if err != nil {
+				return err
+			}
+			dates, _, err = dateOpts.exclude(dates, nil)
+			if err != nil {
+				return err
+			}
+
+			// Dates that are already booked don't need watching
+			upcoming, err := ww.GetUpcomingBookings()
+			if err != nil {
+				return fmt.Errorf("failed to get upcoming bookings: %v", err)
+			}
+			dates, conflicts := splitConflicts(dates, bookingsByDate(upcoming))
+			for _, c := range conflicts {
+				notifier.notify(watchEvent{Event: "skipped", Date: c.Date, Message: c.Reason})
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			// Only spaces with a free seat are candidates, whatever --pick prefers. A space given
+			// by --space-uuid is watched until it has one.
+			var lister spaceLister = ww
+			if selector.SpaceUUID == "" {
+				lister = fittingSpaces{spaceLister: ww, seats: selector.seatCount()}
+			}
+			w := watcher{
+				notify: notifier.notify,
+				find: func(date time.Time) (*wework.Workspace, error) {
+					space, _, err := findSpaceInOrder(lister, quietProgress{}, selector, date, targetLocationUUIDs)
+					return space, err
+				},
+				now:          time.Now,
+				sleep:        sleepContext,
+				pollInterval: pollInterval,
+				jitter:       jitter,
+				until:        until,
+				jsonOut:      jsonOut,
+			}
+			if book {
+				w.book = func(date time.Time, space *wework.Workspace) bookResult {
+					pinned := selector
+					pinned.SpaceUUID = space.UUID
+					return bookDate(ww, quietProgress{}, pinned, date, []string{space.Location.UUID}, nil, wework.BookingOptions{}, nil)
+				}
+			}
+			return w.run(ctx, dates)
+		},
+	}
+
+	cmd.Flags().StringVar(&locationUUID, "location-uuid", "", "Location UUID to watch; a comma-separated list or a named list from the config watches several in order")
+	cmd.Flags().StringVar(&city, "city", "", "City name")
+	cmd.Flags().StringVar(&name, "name", "", "Space name")
+	dateOpts.addFlags(cmd)
+	cmd.Flags().StringVar(&spaceUUID, "space-uuid", "", "Workspace UUID to watch when a location has multiple spaces")
+	cmd.Flags().StringVar(&pick, "pick", pickMostAvailable, "Strategy when multiple spaces are found: first, most-available or cheapest")
+	cmd.Flags().DurationVar(&pollInterval, "poll-interval", 5*time.Minute, "Time between availability checks")
+	cmd.Flags().DurationVar(&jitter, "jitter", 30*time.Second, "Random extra delay added to each poll interval")
+	cmd.Flags().StringVar(&deadline, "deadline", "", "Stop watching after this duration (e.g. 6h) or time (YYYY-MM-DD HH:MM)")
+	cmd.Flags().BoolVar(&book, "book", false, "Book as soon as a seat is available instead of only notifying")
+	cmd.Flags().StringVar(&webhook, "webhook", "", "URL to POST a JSON event to when a seat is found or booked")
+	cmd.Flags().StringVar(&execCmd, "exec", "", "Shell command to run when a seat is found or booked (event details in WEWORK_* env vars)")
+
+	return cmd
+}
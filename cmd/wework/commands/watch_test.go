@@ -0,0 +1,242 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dvcrn/wework-cli/pkg/wework"
+)
+
+func TestParseDeadline(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expected      func() time.Time
+		errorContains string
+	}{
+		{
+			name:     "duration",
+			input:    "6h",
+			expected: func() time.Time { return time.Now().Add(6 * time.Hour) },
+		},
+		{
+			name:     "RFC 3339",
+			input:    "2026-03-16T09:00:00+09:00",
+			expected: func() time.Time { return time.Date(2026, 3, 16, 9, 0, 0, 0, time.FixedZone("JST", 9*60*60)) },
+		},
+		{
+			name:     "local timestamp",
+			input:    "2026-03-16 09:00",
+			expected: func() time.Time { return time.Date(2026, 3, 16, 9, 0, 0, 0, time.Local) },
+		},
+		{
+			name:          "invalid",
+			input:         "tomorrow morning",
+			errorContains: "invalid --deadline 'tomorrow morning'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDeadline(tt.input)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing '%s', got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			// Durations are relative to now, so allow for the time the test takes
+			if diff := got.Sub(tt.expected()); diff < -time.Second || diff > time.Second {
+				t.Errorf("expected %s, got %s", tt.expected(), got)
+			}
+		})
+	}
+}
+
+func TestWatchNotifierExec(t *testing.T) {
+	tests := []struct {
+		name     string
+		ev       watchEvent
+		expected []string
+	}{
+		{
+			name: "available",
+			ev:   watchEvent{Event: "available", Date: "2026-03-16", LocationUUID: "loc-1", LocationName: "Shibuya Scramble Square", SpaceUUID: "space-1", Available: 3},
+			expected: []string{
+				"WEWORK_EVENT=available",
+				"WEWORK_DATE=2026-03-16",
+				"WEWORK_LOCATION_UUID=loc-1",
+				"WEWORK_LOCATION_NAME=Shibuya Scramble Square",
+				"WEWORK_SPACE_UUID=space-1",
+				"WEWORK_SEATS_AVAILABLE=3",
+			},
+		},
+		{
+			name:     "booked",
+			ev:       watchEvent{Event: "booked", Date: "2026-03-17", LocationUUID: "loc-2"},
+			expected: []string{"WEWORK_EVENT=booked", "WEWORK_DATE=2026-03-17", "WEWORK_LOCATION_UUID=loc-2", "WEWORK_SEATS_AVAILABLE=0"},
+		},
+		{
+			// Progress events don't run hooks
+			name: "error",
+			ev:   watchEvent{Event: "error", Date: "2026-03-18", Message: "unauthorized"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), "env")
+			n := watchNotifier{jsonOut: true, execCmd: "env | grep '^WEWORK_' > " + out}
+			n.notify(tt.ev)
+
+			b, err := os.ReadFile(out)
+			if len(tt.expected) == 0 {
+				if err == nil {
+					t.Fatalf("expected no hook to run, got %q", b)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("hook did not run: %v", err)
+			}
+			env := strings.Split(strings.TrimSpace(string(b)), "\n")
+			for _, want := range tt.expected {
+				found := false
+				for _, line := range env {
+					found = found || line == want
+				}
+				if !found {
+					t.Errorf("expected %s in hook environment, got %v", want, env)
+				}
+			}
+		})
+	}
+}
+
+func TestWatcherRun(t *testing.T) {
+	start := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	date := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+	free := &wework.Workspace{UUID: "space-1", Seat: wework.Seat{Available: 2}, Location: wework.Location{UUID: "loc-1", Name: "Shibuya"}}
+
+	tests := []struct {
+		name string
+		// finds holds what each poll finds; the last entry repeats
+		finds         []error
+		book          bool
+		bookError     string
+		until         time.Duration
+		events        string
+		polls         int
+		errorContains string
+	}{
+		{
+			name:   "becomes available and is booked",
+			finds:  []error{noSeatsError("no seats available"), noSeatsError("no seats available"), nil},
+			book:   true,
+			events: "booked",
+			polls:  3,
+		},
+		{
+			name:   "becomes available and is reported",
+			finds:  []error{noSeatsError("no seats available"), nil},
+			events: "available",
+			polls:  2,
+		},
+		{
+			name:          "failed booking is retried",
+			finds:         []error{nil},
+			book:          true,
+			bookError:     "booking failed",
+			until:         90 * time.Second,
+			events:        "failed,failed,deadline",
+			polls:         2,
+			errorContains: "deadline reached",
+		},
+		{
+			name:          "deadline expires",
+			finds:         []error{noSeatsError("no seats available")},
+			until:         150 * time.Second,
+			events:        "deadline",
+			polls:         3,
+			errorContains: "deadline reached with 1 date(s) still unsecured",
+		},
+		{
+			name:          "repeated failures",
+			finds:         []error{fmt.Errorf("unauthorized")},
+			events:        "error,error,error,error,error",
+			polls:         watchMaxFailedPolls,
+			errorContains: "giving up after 5 polls",
+		},
+		{
+			name:   "a failure between checks doesn't count",
+			finds:  []error{fmt.Errorf("unauthorized"), noSeatsError("no seats available"), fmt.Errorf("unauthorized"), nil},
+			events: "error,error,available",
+			polls:  4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := start
+			polls := 0
+			var events []string
+			w := watcher{
+				notify: func(ev watchEvent) { events = append(events, ev.Event) },
+				find: func(time.Time) (*wework.Workspace, error) {
+					err := tt.finds[min(polls, len(tt.finds)-1)]
+					polls++
+					if err != nil {
+						return nil, err
+					}
+					return free, nil
+				},
+				now: func() time.Time { return now },
+				sleep: func(_ context.Context, d time.Duration) error {
+					now = now.Add(d)
+					return nil
+				},
+				pollInterval: time.Minute,
+				jsonOut:      true,
+			}
+			if tt.until > 0 {
+				w.until = start.Add(tt.until)
+			}
+			if tt.book {
+				w.book = func(date time.Time, space *wework.Workspace) bookResult {
+					if space.UUID != free.UUID {
+						t.Errorf("expected to book %s, got %s", free.UUID, space.UUID)
+					}
+					if tt.bookError != "" {
+						return bookResult{Error: tt.bookError}
+					}
+					return bookResult{BookingStatus: &wework.BookingResponse{ReservationID: "r-1"}}
+				}
+			}
+
+			err := w.run(context.Background(), []time.Time{date})
+			if got := strings.Join(events, ","); got != tt.events {
+				t.Errorf("expected events %s, got %s", tt.events, got)
+			}
+			if polls != tt.polls {
+				t.Errorf("expected %d polls, got %d", tt.polls, polls)
+			}
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing '%s', got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
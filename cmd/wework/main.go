@@ -20,6 +20,7 @@ var (
 	calendarPath     string
 	includeBootstrap bool
 	outputJSON       bool
+	profile          string
 )
 
 func main() {
@@ -27,30 +28,54 @@ func main() {
 		Use:   "wework",
 		Short: "WeWork CLI tool",
 		Long:  `A command line interface for WeWork workspace booking and management.`,
+
+		// Errors are printed once below, with an exit status that tells failures apart
+		SilenceErrors: true,
+		SilenceUsage:  true,
 	}
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &commands.ExitError{Code: commands.ExitUsage, Err: err}
+	})
 
 	rootCmd.PersistentFlags().StringVar(&username, "username", os.Getenv("WEWORK_USERNAME"), "WeWork username")
 	rootCmd.PersistentFlags().StringVar(&password, "password", os.Getenv("WEWORK_PASSWORD"), "WeWork password")
 	rootCmd.PersistentFlags().BoolVar(&outputJSON, "json", false, "Output JSON instead of text (disables spinners)")
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", os.Getenv("WEWORK_PROFILE"), "Config profile with booking defaults")
 
 	rootCmd.AddCommand(
 		commands.NewLocationsCommand(authenticate),
 		commands.NewDesksCommand(authenticate),
 		commands.NewBookingsCommand(authenticate),
 		commands.NewBookCommand(authenticate),
+		commands.NewCancelCommand(authenticate),
 		commands.NewCalendarCommand(authenticate),
 		commands.NewMeCommand(authenticate),
 		commands.NewInfoCommand(authenticate),
 		commands.NewQuoteCommand(authenticate),
+		commands.NewWatchCommand(authenticate),
+		commands.NewDebugCommand(authenticate),
 	)
 
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+	if cmd, err := rootCmd.ExecuteC(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		code := commands.ExitCode(err)
+		if code == commands.ExitUsage {
+			fmt.Fprintf(os.Stderr, "Run '%s --help' for usage.\n", cmd.CommandPath())
+		}
+		os.Exit(code)
 	}
 }
 
+// authenticate logs in with the credentials from the flags or environment. Its errors exit with commands.ExitAuth.
 func authenticate() (*wework.WeWork, error) {
+	ww, err := login()
+	if err != nil {
+		return nil, &commands.ExitError{Code: commands.ExitAuth, Err: err}
+	}
+	return ww, nil
+}
+
+func login() (*wework.WeWork, error) {
 	if username == "" || password == "" {
 		return nil, fmt.Errorf("username and password are required. Set WEWORK_USERNAME and WEWORK_PASSWORD environment variables or use --username and --password flags")
 	}
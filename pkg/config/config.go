@@ -0,0 +1,115 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Config is the optional user configuration, read from $WEWORK_CONFIG or
+// wework/config.json in the user config directory (e.g. ~/.config/wework/config.json).
+type Config struct {
+	// Locations maps a name to an ordered list of location UUIDs. A name can be passed
+	// to `book --location-uuid`; later entries are fallbacks for earlier ones.
+	Locations map[string][]string `json:"locations,omitempty"`
+
+	// Profiles holds named sets of booking defaults, selected with --profile.
+	Profiles map[string]Profile `json:"profiles,omitempty"`
+	// DefaultProfile is used when --profile is not given.
+	DefaultProfile string `json:"defaultProfile,omitempty"`
+
+	// MonthlyCreditBudget caps the credits `book` may spend per calendar month, counting
+	// existing bookings. Zero means no budget.
+	MonthlyCreditBudget float64 `json:"monthlyCreditBudget,omitempty"`
+}
+
+// Profile holds booking defaults, e.g. per client or project.
+type Profile struct {
+	// Note is the default booking note. Placeholders like {date}, {location} or any key of
+	// Vars (e.g. {project}) are filled in for each booking.
+	Note string            `json:"note,omitempty"`
+	Vars map[string]string `json:"vars,omitempty"`
+}
+
+// Path returns the location of the config file.
+func Path() (string, error) {
+	if p := os.Getenv("WEWORK_CONFIG"); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine config directory: %w", err)
+	}
+	return filepath.Join(dir, "wework", "config.json"), nil
+}
+
+// QuotesDir returns the directory where `quote` saves quotes for `book --quote`:
+// $WEWORK_QUOTES_DIR or wework/quotes in the user cache directory.
+func QuotesDir() (string, error) {
+	if p := os.Getenv("WEWORK_QUOTES_DIR"); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine cache directory: %w", err)
+	}
+	return filepath.Join(dir, "wework", "quotes"), nil
+}
+
+// Load reads the config file. A missing file yields an empty config.
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// ExpandLocations replaces named location lists with their UUIDs, keeping order and dropping duplicates.
+func (c *Config) ExpandLocations(refs []string) []string {
+	var uuids []string
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		expanded := []string{ref}
+		if list, ok := c.Locations[ref]; ok {
+			expanded = list
+		}
+		for _, uuid := range expanded {
+			if uuid != "" && !seen[uuid] {
+				seen[uuid] = true
+				uuids = append(uuids, uuid)
+			}
+		}
+	}
+	return uuids
+}
+
+// Profile returns the named profile, or the default profile if name is empty.
+func (c *Config) Profile(name string) (Profile, error) {
+	if name == "" {
+		name = c.DefaultProfile
+	}
+	if name == "" {
+		return Profile{}, nil
+	}
+	p, ok := c.Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("profile '%s' not found in config", name)
+	}
+	return p, nil
+}
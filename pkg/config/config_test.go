@@ -0,0 +1,78 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExpandLocations(t *testing.T) {
+	cfg := &Config{Locations: map[string][]string{
+		"tokyo":  {"shibuya", "roppongi", "ginza"},
+		"office": {"ginza", "shinjuku"},
+		"empty":  {},
+	}}
+
+	tests := []struct {
+		name     string
+		refs     []string
+		expected string
+	}{
+		{name: "plain uuids", refs: []string{"uuid-1", "uuid-2"}, expected: "uuid-1,uuid-2"},
+		{name: "alias keeps order", refs: []string{"tokyo"}, expected: "shibuya,roppongi,ginza"},
+		{name: "alias mixed with uuids", refs: []string{"uuid-1", "office"}, expected: "uuid-1,ginza,shinjuku"},
+		{name: "duplicates dropped", refs: []string{"tokyo", "office", "shibuya"}, expected: "shibuya,roppongi,ginza,shinjuku"},
+		{name: "empty alias", refs: []string{"empty", "uuid-1"}, expected: "uuid-1"},
+		{name: "blank refs", refs: []string{"", "uuid-1"}, expected: "uuid-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := strings.Join(cfg.ExpandLocations(tt.refs), ","); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name          string
+		path          string
+		locations     string
+		errorContains string
+	}{
+		{name: "missing file", path: filepath.Join(dir, "missing.json")},
+		{name: "named locations", path: write("config.json", `{"locations": {"tokyo": ["shibuya", "ginza"]}}`), locations: "shibuya,ginza"},
+		{name: "invalid json", path: write("invalid.json", `{"locations": [}`), errorContains: "failed to parse config"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("WEWORK_CONFIG", tt.path)
+			cfg, err := Load()
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing '%s', got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := strings.Join(cfg.Locations["tokyo"], ","); got != tt.locations {
+				t.Errorf("expected tokyo locations %q, got %q", tt.locations, got)
+			}
+		})
+	}
+}
@@ -156,6 +156,27 @@ func (cs *ContinuousSpinner) Println(args ...any) {
 	}
 }
 
+// Suspend hands the terminal back while fn runs (e.g. to prompt the user) and resumes the spinner afterwards
+func (cs *ContinuousSpinner) Suspend(fn func() error) error {
+	if !cs.isTerminal || cs.noSpinner {
+		return fn()
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if cs.program == nil || cs.finished {
+		return fn()
+	}
+
+	if err := cs.program.ReleaseTerminal(); err != nil {
+		return err
+	}
+	defer cs.program.RestoreTerminal()
+
+	return fn()
+}
+
 // Success stops the spinner with a success message
 func (cs *ContinuousSpinner) Success(message string) {
 	if !cs.isTerminal || cs.noSpinner {
@@ -0,0 +1,100 @@
+package tzdate
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	ics "github.com/arran4/golang-ical"
+)
+
+// Holidays maps calendar days ("YYYY-MM-DD") to the holiday's name.
+type Holidays map[string]string
+
+// LoadHolidays reads holidays from spec, which is either an ICS file, a text file with one
+// YYYY-MM-DD date per line (optionally followed by a name, "#" starts a comment), or an
+// inline comma-separated list of dates.
+func LoadHolidays(spec string) (Holidays, error) {
+	holidays := Holidays{}
+
+	if _, err := os.Stat(spec); err != nil {
+		// Not a file: treat it as an inline date list
+		for _, d := range strings.Split(spec, ",") {
+			if err := holidays.add(strings.TrimSpace(d), "holiday"); err != nil {
+				return nil, err
+			}
+		}
+		return holidays, nil
+	}
+
+	f, err := os.Open(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open holiday file: %w", err)
+	}
+	defer f.Close()
+
+	if strings.HasSuffix(strings.ToLower(spec), ".ics") {
+		cal, err := ics.ParseCalendar(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse holiday calendar %s: %w", spec, err)
+		}
+		for _, event := range cal.Events() {
+			start, err := event.GetAllDayStartAt()
+			if err != nil {
+				continue
+			}
+			name := "holiday"
+			if summary := event.GetProperty(ics.ComponentPropertySummary); summary != nil {
+				name = summary.Value
+			}
+			// DTEND is exclusive; single-day events usually omit it
+			end, err := event.GetAllDayEndAt()
+			if err != nil || !end.After(start) {
+				end = start.AddDate(0, 0, 1)
+			}
+			for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+				holidays[d.Format("2006-01-02")] = name
+			}
+		}
+		return holidays, nil
+	}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		date, name, _ := strings.Cut(line, " ")
+		if name = strings.TrimSpace(name); name == "" {
+			name = "holiday"
+		}
+		if err := holidays.add(date, name); err != nil {
+			return nil, fmt.Errorf("%s: %w", spec, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read holiday file: %w", err)
+	}
+	return holidays, nil
+}
+
+func (h Holidays) add(date, name string) error {
+	if date == "" {
+		return nil
+	}
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return fmt.Errorf("invalid holiday date '%s'", date)
+	}
+	h[date] = name
+	return nil
+}
+
+// Name returns the holiday falling on t's calendar day, if any.
+func (h Holidays) Name(t time.Time) (string, bool) {
+	name, ok := h[t.Format("2006-01-02")]
+	return name, ok
+}
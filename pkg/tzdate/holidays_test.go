@@ -0,0 +1,109 @@
+package tzdate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadHolidays(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		return path
+	}
+
+	ics := writeFile("holidays.ics", strings.ReplaceAll(`BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//test//EN
+BEGIN:VEVENT
+UID:1
+DTSTART;VALUE=DATE:20240101
+SUMMARY:New Year's Day
+END:VEVENT
+BEGIN:VEVENT
+UID:2
+DTSTART;VALUE=DATE:20240429
+DTEND;VALUE=DATE:20240501
+SUMMARY:Golden Week
+END:VEVENT
+END:VCALENDAR
+`, "\n", "\r\n"))
+	text := writeFile("holidays.txt", `# company holidays
+2024-01-01 New Year
+2024-12-25
+
+2024-12-31 Year end # office closed
+`)
+	invalid := writeFile("invalid.txt", "2024-13-01 Nope\n")
+
+	tests := []struct {
+		name          string
+		spec          string
+		expected      map[string]string
+		errorContains string
+	}{
+		{
+			name: "ics with multi-day event",
+			spec: ics,
+			expected: map[string]string{
+				"2024-01-01": "New Year's Day",
+				"2024-04-29": "Golden Week",
+				"2024-04-30": "Golden Week",
+			},
+		},
+		{
+			name: "text file with names and comments",
+			spec: text,
+			expected: map[string]string{
+				"2024-01-01": "New Year",
+				"2024-12-25": "holiday",
+				"2024-12-31": "Year end",
+			},
+		},
+		{
+			name:     "inline list",
+			spec:     "2024-05-01, 2024-05-03",
+			expected: map[string]string{"2024-05-01": "holiday", "2024-05-03": "holiday"},
+		},
+		{
+			name:          "invalid inline date",
+			spec:          "2024-05-01,tomorrow",
+			errorContains: "invalid holiday date 'tomorrow'",
+		},
+		{
+			name:          "invalid date in file",
+			spec:          invalid,
+			errorContains: "invalid holiday date '2024-13-01'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holidays, err := LoadHolidays(tt.spec)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing '%s', got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(holidays) != len(tt.expected) {
+				t.Errorf("expected %d holidays, got %v", len(tt.expected), holidays)
+			}
+			for date, name := range tt.expected {
+				d, _ := time.Parse("2006-01-02", date)
+				if got, ok := holidays.Name(d); !ok || got != name {
+					t.Errorf("%s: expected %q, got %q (found %v)", date, name, got, ok)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package tzdate
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	relativeRe = regexp.MustCompile(`^([+-])(\d+)([dw])$`)
+	isoWeekRe  = regexp.MustCompile(`^(\d{4})-?W(\d{2})(?:-?([1-7]))?$`)
+)
+
+// ParseDates parses a date expression into the calendar days it covers, at midnight in now's location.
+//
+// An expression is a comma-separated list of terms, where each term is either a single
+// day or a range "a~b" between two single days. Supported single days are YYYY-MM-DD,
+// "today", "tomorrow", "yesterday", weekday names ("friday", "next monday", "last tuesday"),
+// relative offsets ("+3d", "-1d", "+2w") and ISO week days ("2024-W23-2"). Terms may also
+// be whole weeks: "this week", "next week", "last week" or an ISO week ("2024-W23").
+func ParseDates(expr string, now time.Time) ([]time.Time, error) {
+	today := startOfDay(now)
+
+	var dates []time.Time
+	seen := make(map[string]bool)
+	add := func(d time.Time) {
+		key := d.Format("2006-01-02")
+		if !seen[key] {
+			seen[key] = true
+			dates = append(dates, d)
+		}
+	}
+
+	for _, term := range strings.Split(expr, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		if from, to, ok := strings.Cut(term, "~"); ok {
+			start, err := parseDay(from, today)
+			if err != nil {
+				return nil, fmt.Errorf("invalid start date: %w", err)
+			}
+			end, err := parseDay(to, today)
+			if err != nil {
+				return nil, fmt.Errorf("invalid end date: %w", err)
+			}
+			if end.Before(start) {
+				return nil, fmt.Errorf("invalid date range: %s is before %s", end.Format("2006-01-02"), start.Format("2006-01-02"))
+			}
+			for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+				add(d)
+			}
+			continue
+		}
+
+		days, err := parseTerm(term, today)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range days {
+			add(d)
+		}
+	}
+
+	if len(dates) == 0 {
+		return nil, fmt.Errorf("no dates given")
+	}
+	return dates, nil
+}
+
+// ParseDate parses an expression that must resolve to exactly one calendar day.
+func ParseDate(expr string, now time.Time) (time.Time, error) {
+	dates, err := ParseDates(expr, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(dates) != 1 {
+		return time.Time{}, fmt.Errorf("'%s' is %d dates, expected a single date", expr, len(dates))
+	}
+	return dates[0], nil
+}
+
+func parseTerm(term string, today time.Time) ([]time.Time, error) {
+	lower := strings.ToLower(term)
+	switch lower {
+	case "this week":
+		return weekDays(startOfWeek(today)), nil
+	case "next week":
+		return weekDays(startOfWeek(today).AddDate(0, 0, 7)), nil
+	case "last week":
+		return weekDays(startOfWeek(today).AddDate(0, 0, -7)), nil
+	}
+
+	if m := isoWeekRe.FindStringSubmatch(strings.ToUpper(term)); m != nil && m[3] == "" {
+		monday, err := isoWeekMonday(m[1], m[2], today.Location())
+		if err != nil {
+			return nil, err
+		}
+		return weekDays(monday), nil
+	}
+
+	d, err := parseDay(term, today)
+	if err != nil {
+		return nil, err
+	}
+	return []time.Time{d}, nil
+}
+
+// parseDay parses a term that resolves to a single day.
+func parseDay(term string, today time.Time) (time.Time, error) {
+	term = strings.TrimSpace(term)
+	lower := strings.ToLower(term)
+
+	switch lower {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	if m := relativeRe.FindStringSubmatch(lower); m != nil {
+		n, _ := strconv.Atoi(m[2])
+		if m[1] == "-" {
+			n = -n
+		}
+		if m[3] == "w" {
+			n *= 7
+		}
+		return today.AddDate(0, 0, n), nil
+	}
+
+	if m := isoWeekRe.FindStringSubmatch(strings.ToUpper(term)); m != nil && m[3] != "" {
+		monday, err := isoWeekMonday(m[1], m[2], today.Location())
+		if err != nil {
+			return time.Time{}, err
+		}
+		day, _ := strconv.Atoi(m[3])
+		return monday.AddDate(0, 0, day-1), nil
+	}
+
+	if prefix, name, ok := strings.Cut(lower, " "); ok && (prefix == "next" || prefix == "last") {
+		wd, err := ParseWeekday(name)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date '%s'", term)
+		}
+		if prefix == "next" {
+			diff := (int(wd) - int(today.Weekday()) + 7) % 7
+			if diff == 0 {
+				diff = 7
+			}
+			return today.AddDate(0, 0, diff), nil
+		}
+		diff := (int(today.Weekday()) - int(wd) + 7) % 7
+		if diff == 0 {
+			diff = 7
+		}
+		return today.AddDate(0, 0, -diff), nil
+	}
+
+	if wd, err := ParseWeekday(lower); err == nil {
+		// A bare weekday means its next occurrence, today included
+		return today.AddDate(0, 0, (int(wd)-int(today.Weekday())+7)%7), nil
+	}
+
+	d, err := time.ParseInLocation("2006-01-02", term, today.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date '%s' (expected YYYY-MM-DD, today, tomorrow, next monday, +3d or 2024-W23)", term)
+	}
+	return d, nil
+}
+
+// isoWeekMonday returns the Monday of the given ISO 8601 week.
+func isoWeekMonday(yearStr, weekStr string, loc *time.Location) (time.Time, error) {
+	year, _ := strconv.Atoi(yearStr)
+	week, _ := strconv.Atoi(weekStr)
+
+	// January 4th is always in week 1
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, loc)
+	monday := startOfWeek(jan4).AddDate(0, 0, (week-1)*7)
+	if y, w := monday.ISOWeek(); week < 1 || y != year || w != week {
+		return time.Time{}, fmt.Errorf("invalid ISO week %s-W%s", yearStr, weekStr)
+	}
+	return monday, nil
+}
+
+func weekDays(monday time.Time) []time.Time {
+	days := make([]time.Time, 7)
+	for i := range days {
+		days[i] = monday.AddDate(0, 0, i)
+	}
+	return days
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,69 @@
+package tzdate
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDates(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("failed to load timezone: %v", err)
+	}
+	// Wednesday evening
+	now := time.Date(2024, 6, 5, 22, 30, 0, 0, berlin)
+
+	tests := []struct {
+		expr          string
+		expected      []string
+		errorContains string
+	}{
+		{expr: "2024-06-10", expected: []string{"2024-06-10"}},
+		{expr: "today", expected: []string{"2024-06-05"}},
+		{expr: "tomorrow", expected: []string{"2024-06-06"}},
+		{expr: "next monday", expected: []string{"2024-06-10"}},
+		{expr: "next wednesday", expected: []string{"2024-06-12"}},
+		{expr: "friday", expected: []string{"2024-06-07"}},
+		{expr: "+3d", expected: []string{"2024-06-08"}},
+		{expr: "-1w", expected: []string{"2024-05-29"}},
+		{expr: "this week", expected: []string{"2024-06-03", "2024-06-04", "2024-06-05", "2024-06-06", "2024-06-07", "2024-06-08", "2024-06-09"}},
+		{expr: "next week", expected: []string{"2024-06-10", "2024-06-11", "2024-06-12", "2024-06-13", "2024-06-14", "2024-06-15", "2024-06-16"}},
+		{expr: "2024-W23", expected: []string{"2024-06-03", "2024-06-04", "2024-06-05", "2024-06-06", "2024-06-07", "2024-06-08", "2024-06-09"}},
+		{expr: "2025-W01-1", expected: []string{"2024-12-30"}},
+		{expr: "tomorrow~+4d", expected: []string{"2024-06-06", "2024-06-07", "2024-06-08", "2024-06-09"}},
+		{expr: "2024-06-10, next monday, 2024-06-12", expected: []string{"2024-06-10", "2024-06-12"}},
+		{expr: "2024-W54", errorContains: "invalid ISO week"},
+		{expr: "someday", errorContains: "invalid date"},
+		{expr: "+3d~today", errorContains: "invalid date range"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			dates, err := ParseDates(tt.expr, now)
+			if tt.errorContains != "" {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				if !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("expected error to contain '%s', got '%s'", tt.errorContains, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var actual []string
+			for _, d := range dates {
+				if d.Location() != berlin || d.Hour() != 0 {
+					t.Errorf("expected midnight in %s, got %s", berlin, d)
+				}
+				actual = append(actual, d.Format("2006-01-02"))
+			}
+			if strings.Join(actual, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
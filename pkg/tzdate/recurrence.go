@@ -0,0 +1,201 @@
+package tzdate
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the base repetition unit of a Recurrence.
+type Frequency int
+
+const (
+	Daily Frequency = iota
+	Weekly
+)
+
+// maxOccurrences bounds expansion so a typo in --until can't generate years of bookings.
+const maxOccurrences = 366
+
+// Recurrence describes a repeating set of calendar days, modelled after RFC 5545 RRULEs.
+// Either Until or Count must be set.
+type Recurrence struct {
+	Freq     Frequency
+	Interval int
+	ByDay    []time.Weekday
+	Until    time.Time
+	Count    int
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"su": time.Sunday, "sun": time.Sunday, "sunday": time.Sunday,
+	"mo": time.Monday, "mon": time.Monday, "monday": time.Monday,
+	"tu": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"we": time.Wednesday, "wed": time.Wednesday, "wednesday": time.Wednesday,
+	"th": time.Thursday, "thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fr": time.Friday, "fri": time.Friday, "friday": time.Friday,
+	"sa": time.Saturday, "sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// ParseWeekday parses an English weekday name or abbreviation ("mon", "TU", "friday").
+func ParseWeekday(s string) (time.Weekday, error) {
+	wd, ok := weekdayNames[strings.ToLower(strings.TrimSpace(s))]
+	if !ok {
+		return 0, fmt.Errorf("unknown weekday '%s'", s)
+	}
+	return wd, nil
+}
+
+// ParseWeekdays parses a comma-separated weekday list such as "mon,wed,fri".
+// The shortcuts "weekdays" and "weekends" are also accepted.
+func ParseWeekdays(s string) ([]time.Weekday, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "weekdays":
+		return []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}, nil
+	case "weekends":
+		return []time.Weekday{time.Saturday, time.Sunday}, nil
+	}
+
+	var days []time.Weekday
+	for _, part := range strings.Split(s, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		wd, err := ParseWeekday(part)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(days, wd) {
+			days = append(days, wd)
+		}
+	}
+	if len(days) == 0 {
+		return nil, fmt.Errorf("no weekdays given")
+	}
+	return days, nil
+}
+
+// ParseRRule parses the subset of an RFC 5545 RRULE that makes sense for day bookings:
+// FREQ (DAILY or WEEKLY), INTERVAL, BYDAY, UNTIL and COUNT. A leading "RRULE:" is ignored.
+// UNTIL dates are interpreted as calendar days in loc.
+func ParseRRule(rule string, loc *time.Location) (*Recurrence, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	r := &Recurrence{Freq: Weekly, Interval: 1}
+	hasFreq := false
+
+	for _, part := range strings.Split(rule, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid RRULE part '%s'", part)
+		}
+		switch strings.ToUpper(strings.TrimSpace(key)) {
+		case "FREQ":
+			switch strings.ToUpper(value) {
+			case "DAILY":
+				r.Freq = Daily
+			case "WEEKLY":
+				r.Freq = Weekly
+			default:
+				return nil, fmt.Errorf("unsupported RRULE frequency '%s' (only DAILY and WEEKLY)", value)
+			}
+			hasFreq = true
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid RRULE interval '%s'", value)
+			}
+			r.Interval = n
+		case "BYDAY":
+			days, err := ParseWeekdays(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid RRULE BYDAY: %w", err)
+			}
+			r.ByDay = days
+		case "UNTIL":
+			// Accept both the date form (20240801) and the date-time form (20240801T000000Z)
+			until, err := time.ParseInLocation("20060102", value[:min(len(value), 8)], loc)
+			if err != nil {
+				return nil, fmt.Errorf("invalid RRULE until '%s'", value)
+			}
+			r.Until = until
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid RRULE count '%s'", value)
+			}
+			r.Count = n
+		case "WKST":
+			// Weeks always start on Monday
+		default:
+			return nil, fmt.Errorf("unsupported RRULE part '%s'", key)
+		}
+	}
+
+	if !hasFreq {
+		return nil, fmt.Errorf("RRULE is missing FREQ")
+	}
+	return r, nil
+}
+
+// Expand returns the occurrences of the recurrence starting at start (inclusive), in start's location.
+func (r *Recurrence) Expand(start time.Time) ([]time.Time, error) {
+	if r.Until.IsZero() && r.Count == 0 {
+		return nil, fmt.Errorf("recurrence needs an end: set an until date or a count")
+	}
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	loc := start.Location()
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	var until time.Time
+	if !r.Until.IsZero() {
+		until = time.Date(r.Until.Year(), r.Until.Month(), r.Until.Day(), 0, 0, 0, 0, loc)
+	}
+
+	byDay := r.ByDay
+	if r.Freq == Weekly && len(byDay) == 0 {
+		byDay = []time.Weekday{start.Weekday()}
+	}
+	weekStart := startOfWeek(start)
+
+	var dates []time.Time
+	for i := 0; ; i++ {
+		d := start.AddDate(0, 0, i)
+		if !until.IsZero() && d.After(until) {
+			break
+		}
+		if r.Count > 0 && len(dates) >= r.Count {
+			break
+		}
+		if len(dates) >= maxOccurrences {
+			return nil, fmt.Errorf("recurrence expands to more than %d dates", maxOccurrences)
+		}
+
+		var matches bool
+		switch r.Freq {
+		case Daily:
+			matches = i%interval == 0 && (len(byDay) == 0 || slices.Contains(byDay, d.Weekday()))
+		case Weekly:
+			week := int(startOfWeek(d).Sub(weekStart).Hours()+12) / (24 * 7)
+			matches = week%interval == 0 && slices.Contains(byDay, d.Weekday())
+		}
+		if matches {
+			dates = append(dates, d)
+		}
+	}
+
+	return dates, nil
+}
+
+// startOfWeek returns the Monday of t's week at midnight.
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
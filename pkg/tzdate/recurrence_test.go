@@ -0,0 +1,94 @@
+package tzdate
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecurrenceExpand(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("failed to load timezone: %v", err)
+	}
+	// Monday
+	start := time.Date(2024, 6, 3, 0, 0, 0, 0, tokyo)
+	date := func(s string) time.Time {
+		d, _ := time.ParseInLocation("2006-01-02", s, tokyo)
+		return d
+	}
+
+	tests := []struct {
+		name          string
+		rule          string
+		recurrence    *Recurrence
+		expected      []string
+		errorContains string
+	}{
+		{
+			name:       "weekday set until",
+			recurrence: &Recurrence{Freq: Weekly, ByDay: []time.Weekday{time.Tuesday, time.Thursday}, Until: date("2024-06-14")},
+			expected:   []string{"2024-06-04", "2024-06-06", "2024-06-11", "2024-06-13"},
+		},
+		{
+			name:       "every other week with count",
+			recurrence: &Recurrence{Freq: Weekly, Interval: 2, ByDay: []time.Weekday{time.Monday, time.Friday}, Count: 4},
+			expected:   []string{"2024-06-03", "2024-06-07", "2024-06-17", "2024-06-21"},
+		},
+		{
+			name:     "rrule weekly",
+			rule:     "RRULE:FREQ=WEEKLY;BYDAY=WE;UNTIL=20240620T000000Z",
+			expected: []string{"2024-06-05", "2024-06-12", "2024-06-19"},
+		},
+		{
+			name:     "rrule daily interval",
+			rule:     "FREQ=DAILY;INTERVAL=3;COUNT=3",
+			expected: []string{"2024-06-03", "2024-06-06", "2024-06-09"},
+		},
+		{
+			name:          "missing end",
+			recurrence:    &Recurrence{Freq: Weekly, ByDay: []time.Weekday{time.Monday}},
+			errorContains: "needs an end",
+		},
+		{
+			name:          "unsupported frequency",
+			rule:          "FREQ=MONTHLY;COUNT=2",
+			errorContains: "unsupported RRULE frequency",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.recurrence
+			var err error
+			if tt.rule != "" {
+				r, err = ParseRRule(tt.rule, tokyo)
+			}
+			var dates []time.Time
+			if err == nil {
+				dates, err = r.Expand(start)
+			}
+
+			if tt.errorContains != "" {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				if !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("expected error to contain '%s', got '%s'", tt.errorContains, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var actual []string
+			for _, d := range dates {
+				actual = append(actual, d.Format("2006-01-02"))
+			}
+			if strings.Join(actual, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
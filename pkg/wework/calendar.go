@@ -3,6 +3,7 @@ package wework
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	ics "github.com/arran4/golang-ical"
 )
@@ -38,46 +39,12 @@ func (w *WeWorkCalendar) GenerateCalendar(outputPath string) error {
 		pastBookings = pastBookings[:10]
 	}
 
-	// Merge bookings
-	allBookings := append(pastBookings, upcomingBookings...)
+	// Merge bookings; a multi-day booking in progress can be listed as both past and upcoming
+	allBookings := MergeMultiday(append(pastBookings, upcomingBookings...))
 
 	// Create events for each booking
 	for _, booking := range allBookings {
-		event := cal.AddEvent(booking.UUID)
-		event.SetSummary(fmt.Sprintf("WeWork: %s", booking.Reservable.Location.Name))
-
-		event.SetProperty(ics.ComponentProperty("DTSTART;TZID="+booking.Reservable.Location.TimeZone),
-			booking.StartsAt.Format("20060102"))
-		event.SetProperty(ics.ComponentProperty("DTEND;TZID="+booking.Reservable.Location.TimeZone),
-			booking.StartsAt.Format("20060102"))
-
-		event.SetProperty(ics.ComponentProperty("TZID"), booking.Reservable.Location.TimeZone)
-
-		// Set Microsoft and Apple specific properties
-		event.SetProperty("X-MICROSOFT-CDO-ALLDAYEVENT", "TRUE")
-		event.SetProperty("X-MICROSOFT-CDO-BUSYSTATUS", "FREE")
-		event.SetProperty("X-MICROSOFT-CDO-IMPORTANCE", "1")
-		event.SetProperty("X-MICROSOFT-DISALLOW-COUNTER", "TRUE")
-		event.SetProperty("X-APPLE-TRAVEL-ADVISORY-BEHAVIOR", "DISABLED")
-		event.SetProperty("X-MOZ-LASTACK", "0")
-
-		// Set transparency and URL
-		event.SetProperty("TRANSP", "TRANSPARENT")
-		event.SetProperty("URL", "https://members.wework.com/workplaceone/content2/your-bookings")
-
-		// Set location
-		event.SetLocation(booking.Reservable.Location.Address.Line1)
-
-		// Set description
-		description := fmt.Sprintf(
-			"WeWork Booking Details:\nLocation: %s\nAddress: %s\nTime: %s - %s\nBooking ID: %s",
-			booking.Reservable.Location.Name,
-			booking.Reservable.Location.Address.Line1,
-			booking.StartsAt.Format("03:04 PM"),
-			booking.EndsAt.Format("03:04 PM"),
-			booking.UUID,
-		)
-		event.SetDescription(description)
+		addBookingEvent(cal, booking)
 	}
 
 	// Write to file
@@ -89,3 +56,64 @@ func (w *WeWorkCalendar) GenerateCalendar(outputPath string) error {
 
 	return cal.SerializeTo(f)
 }
+
+// addBookingEvent adds booking to cal as an all-day event.
+func addBookingEvent(cal *ics.Calendar, booking *Booking) {
+	event := cal.AddEvent(booking.UUID)
+	event.SetSummary(fmt.Sprintf("WeWork: %s", booking.Reservable.Location.Name))
+
+	event.SetProperty(ics.ComponentProperty("DTSTART;TZID="+booking.Reservable.Location.TimeZone),
+		booking.StartsAt.Format("20060102"))
+	// A multi-day booking is one event spanning all of its days; DTEND is exclusive
+	lastDay := booking.StartsAt.Time
+	if days := booking.Days(); len(days) > 0 {
+		lastDay = days[len(days)-1]
+	}
+	event.SetProperty(ics.ComponentProperty("DTEND;TZID="+booking.Reservable.Location.TimeZone),
+		lastDay.AddDate(0, 0, 1).Format("20060102"))
+
+	event.SetProperty(ics.ComponentProperty("TZID"), booking.Reservable.Location.TimeZone)
+
+	// Set Microsoft and Apple specific properties
+	event.SetProperty("X-MICROSOFT-CDO-ALLDAYEVENT", "TRUE")
+	event.SetProperty("X-MICROSOFT-CDO-BUSYSTATUS", "FREE")
+	event.SetProperty("X-MICROSOFT-CDO-IMPORTANCE", "1")
+	event.SetProperty("X-MICROSOFT-DISALLOW-COUNTER", "TRUE")
+	event.SetProperty("X-APPLE-TRAVEL-ADVISORY-BEHAVIOR", "DISABLED")
+	event.SetProperty("X-MOZ-LASTACK", "0")
+
+	// Set transparency and URL
+	event.SetProperty("TRANSP", "TRANSPARENT")
+	event.SetProperty("URL", "https://members.wework.com/workplaceone/content2/your-bookings")
+
+	// Set location
+	event.SetLocation(booking.Reservable.Location.Address.Line1)
+
+	// Set description
+	description := fmt.Sprintf(
+		"WeWork Booking Details:\nLocation: %s\nAddress: %s\nTime: %s - %s\nBooking ID: %s",
+		booking.Reservable.Location.Name,
+		booking.Reservable.Location.Address.Line1,
+		booking.StartsAt.Format("03:04 PM"),
+		booking.EndsAt.Format("03:04 PM"),
+		booking.UUID,
+	)
+	if booking.IsMultidayBooking {
+		description += fmt.Sprintf("\nDates: %s - %s", booking.StartsAt.Format("2006-01-02"), lastDay.Format("2006-01-02"))
+	}
+	if booking.Notes != "" {
+		description += "\nNote: " + booking.Notes
+	}
+	if len(booking.Guests) > 0 {
+		description += "\nGuests:"
+		for _, g := range booking.Guests {
+			guest := g.Name
+			if g.Email != "" {
+				guest = strings.TrimSpace(fmt.Sprintf("%s <%s>", g.Name, g.Email))
+				event.AddAttendee(g.Email, ics.WithCN(g.Name))
+			}
+			description += "\n- " + guest
+		}
+	}
+	event.SetDescription(description)
+}
@@ -0,0 +1,54 @@
+package wework
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	ics "github.com/arran4/golang-ical"
+)
+
+func TestAddBookingEvent(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(day, hour int) CustomTime {
+		return CustomTime{Time: time.Date(2026, 3, day, hour, 0, 0, 0, tokyo)}
+	}
+	reservable := &SharedWorkspace{Location: &SharedWorkspaceLocation{Name: "Shibuya", TimeZone: "Asia/Tokyo"}}
+
+	tests := []struct {
+		name    string
+		booking *Booking
+		dtstart string
+		dtend   string
+	}{
+		{
+			name:    "single day",
+			booking: &Booking{UUID: "single", StartsAt: at(16, 9), EndsAt: at(16, 18), Reservable: reservable},
+			dtstart: "DTSTART;TZID=Asia/Tokyo:20260316",
+			dtend:   "DTEND;TZID=Asia/Tokyo:20260317",
+		},
+		{
+			// DTEND is exclusive, so the event ends the day after the last booked day
+			name:    "three days",
+			booking: &Booking{UUID: "multi", StartsAt: at(16, 9), EndsAt: at(18, 18), IsMultidayBooking: true, Reservable: reservable},
+			dtstart: "DTSTART;TZID=Asia/Tokyo:20260316",
+			dtend:   "DTEND;TZID=Asia/Tokyo:20260319",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := ics.NewCalendar()
+			addBookingEvent(cal, tt.booking)
+			out := cal.Serialize()
+			for _, want := range []string{tt.dtstart, tt.dtend} {
+				if !strings.Contains(out, want) {
+					t.Errorf("expected %s in calendar, got:\n%s", want, out)
+				}
+			}
+		})
+	}
+}
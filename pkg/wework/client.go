@@ -24,8 +24,8 @@ type WeWork struct {
 
 // QuoteParameters holds the dynamically determined parameters for a booking quote.
 type QuoteParameters struct {
-	LocationType int
-	SpaceID      string
+	LocationType int    `json:"locationType"`
+	SpaceID      string `json:"spaceID"`
 }
 
 func NewWeWork(token string) *WeWork {
@@ -295,7 +295,7 @@ func (w *WeWork) GetUpcomingBookings() ([]*Booking, error) {
 		w.adjustBookingTimezone(booking)
 	}
 
-	return result.Bookings, nil
+	return MergeMultiday(result.Bookings), nil
 }
 
 func (w *WeWork) adjustBookingTimezone(booking *Booking) {
@@ -339,7 +339,7 @@ func (w *WeWork) GetPastBookingsWithDates(startDate, endDate time.Time) ([]*Book
 		w.adjustBookingTimezone(booking)
 	}
 
-	return result.Bookings, nil
+	return MergeMultiday(result.Bookings), nil
 }
 
 func (w *WeWork) GetBootstrap() (*AppBootstrapResponse, error) {
@@ -411,85 +411,55 @@ func (w *WeWork) GetUserProfile() (*UserProfileResponse, error) {
 }
 
 func (w *WeWork) PostBooking(date time.Time, space *Workspace) (*BookingResponse, error) {
+	return w.PostBookingWithOptions(date, space, BookingOptions{})
+}
+
+// PostBookingWithOptions books space on date, e.g. for several seats or with guests.
+func (w *WeWork) PostBookingWithOptions(date time.Time, space *Workspace, opts BookingOptions) (*BookingResponse, error) {
 	// First get the quote
-	quote, err := w.getBookingQuote(date, space)
+	quote, err := w.getBookingQuote(date, space, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get booking quote: %v", err)
 	}
 
 	// Then create the booking
-	return w.createBooking(date, space, quote)
+	return w.createBooking(date, space, quote, opts)
+}
+
+// PostBookingWithQuote books space on date using a quote obtained beforehand, e.g. after checking its cost.
+func (w *WeWork) PostBookingWithQuote(date time.Time, space *Workspace, quote *QuoteResponse, opts BookingOptions) (*BookingResponse, error) {
+	if quote == nil {
+		return nil, fmt.Errorf("quote cannot be nil")
+	}
+	return w.createBooking(date, space, quote, opts)
 }
 
 // GetBookingQuote returns the booking quote for a given workspace and date, without creating a booking.
 func (w *WeWork) GetBookingQuote(date time.Time, space *Workspace) (*QuoteResponse, error) {
-	return w.getBookingQuote(date, space)
+	return w.getBookingQuote(date, space, BookingOptions{})
 }
 
-// getQuoteParameters determines the correct LocationType and SpaceID for a quote.
-func getQuoteParameters(space *Workspace) (QuoteParameters, error) {
-	if space == nil {
-		return QuoteParameters{}, fmt.Errorf("workspace cannot be nil")
-	}
-
-	params := QuoteParameters{
-		LocationType: space.Location.AccountType,
-	}
-
-	// Use InventoryUUID as the primary SpaceID (Tokyo dump shows this is what's actually used)
-	// Fall back to UUID if InventoryUUID is empty
-	if space.InventoryUUID != "" {
-		params.SpaceID = space.InventoryUUID
-	} else {
-		params.SpaceID = space.UUID
-	}
-
-	return params, nil
+// GetBookingQuoteWithOptions returns the booking quote for the seats and guests in opts.
+func (w *WeWork) GetBookingQuoteWithOptions(date time.Time, space *Workspace, opts BookingOptions) (*QuoteResponse, error) {
+	return w.getBookingQuote(date, space, opts)
 }
 
-// getBookingSpaceID determines the correct SpaceID for a booking based on LocationType.
-// Different WeWork regions/systems use different ID fields for bookings.
-func getBookingSpaceID(space *Workspace) string {
-	switch space.Location.AccountType {
-	case 2:
-		// LocationType 2 (e.g., Munich) - use KubeId if available
-		if space.Reservable != nil && space.Reservable.KubeId != "" {
-			return space.Reservable.KubeId
-		}
-		// Fallback to inventoryUuid
-		if space.InventoryUUID != "" {
-			return space.InventoryUUID
-		}
-		return space.UUID
-
-	case 4:
-		// LocationType 4 (e.g., Tokyo) - use inventoryUuid
-		if space.InventoryUUID != "" {
-			return space.InventoryUUID
-		}
-		// Fallback to uuid
-		return space.UUID
-
-	case 0:
-		// LocationType 0 (e.g., Bangkok) - use uuid
-		return space.UUID
-
-	default:
-		// Unknown LocationType - try inventoryUuid first, then uuid
-		if space.InventoryUUID != "" {
-			return space.InventoryUUID
-		}
-		return space.UUID
-	}
+// BookingWindow returns the start and end of a full-day booking of space on the given date,
+// expressed in the location's timezone.
+func BookingWindow(date time.Time, space *Workspace) (time.Time, time.Time, error) {
+	return BookingOptions{}.Window(date, space)
 }
 
-func (w *WeWork) getBookingQuote(date time.Time, space *Workspace) (*QuoteResponse, error) {
+// Window returns the start and end of the booking of space on the given date in the location's
+// timezone, using StartTime and EndTime when set and the opening hours otherwise. A multi-day
+// booking ends on EndDate. Dates are taken as calendar days, whatever timezone they were parsed in.
+func (o BookingOptions) Window(date time.Time, space *Workspace) (time.Time, time.Time, error) {
 	loc, err := time.LoadLocation(space.Location.TimeZone)
 	if err != nil {
-		return nil, err
+		return time.Time{}, time.Time{}, err
 	}
 
-	dateInTz := date.In(loc)
+	dateInTz := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
 	// Parse open and close times (e.g., "08:30" and "20:00")
 	openHour, openMin := 8, 30 // Default values
 	if len(space.OpenTime) >= 5 {
@@ -500,9 +470,53 @@ func (w *WeWork) getBookingQuote(date time.Time, space *Workspace) (*QuoteRespon
 		fmt.Sscanf(space.CloseTime, "%d:%d", &closeHour, &closeMin)
 	}
 
-	// Create start and end times in local timezone
-	startLocal := time.Date(dateInTz.Year(), dateInTz.Month(), dateInTz.Day(), openHour, openMin, 0, 0, loc)
-	endLocal := time.Date(dateInTz.Year(), dateInTz.Month(), dateInTz.Day(), closeHour, closeMin, 0, 0, loc)
+	openLocal := time.Date(dateInTz.Year(), dateInTz.Month(), dateInTz.Day(), openHour, openMin, 0, 0, loc)
+	endDay := dateInTz
+	if o.multiday(date) {
+		endDay = time.Date(o.EndDate.Year(), o.EndDate.Month(), o.EndDate.Day(), 0, 0, 0, 0, loc)
+	}
+	closeLocal := time.Date(endDay.Year(), endDay.Month(), endDay.Day(), closeHour, closeMin, 0, 0, loc)
+	if o.StartTime == "" && o.EndTime == "" {
+		return openLocal, closeLocal, nil
+	}
+
+	startLocal, endLocal := openLocal, closeLocal
+	if o.StartTime != "" {
+		if startLocal, err = clockOn(dateInTz, o.StartTime, loc); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	if o.EndTime != "" {
+		if endLocal, err = clockOn(endDay, o.EndTime, loc); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+
+	if !endLocal.After(startLocal) {
+		return time.Time{}, time.Time{}, fmt.Errorf("booking end %s is not after start %s", endLocal.Format("15:04"), startLocal.Format("15:04"))
+	}
+	if startLocal.Before(openLocal) || endLocal.After(closeLocal) {
+		return time.Time{}, time.Time{}, fmt.Errorf("booking %s-%s is outside opening hours %s-%s",
+			startLocal.Format("15:04"), endLocal.Format("15:04"), openLocal.Format("15:04"), closeLocal.Format("15:04"))
+	}
+	return startLocal, endLocal, nil
+}
+
+// clockOn returns the given "15:04" time of day on day's date.
+func clockOn(day time.Time, clock string, loc *time.Location) (time.Time, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time '%s' (expected HH:MM)", clock)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, loc), nil
+}
+
+func (w *WeWork) getBookingQuote(date time.Time, space *Workspace, opts BookingOptions) (*QuoteResponse, error) {
+	startLocal, endLocal, err := opts.Window(date, space)
+	if err != nil {
+		return nil, err
+	}
+	dateInTz := startLocal
 
 	// Convert to UTC
 	startTime := startLocal.UTC().Format("2006-01-02T15:04:05Z")
@@ -518,33 +532,39 @@ func (w *WeWork) getBookingQuote(date time.Time, space *Workspace) (*QuoteRespon
 		"SpaceType":            4,
 		"ReservationID":        "",
 		"TriggerCalendarEvent": true,
-		"Notes":                nil,
+		"Notes":                opts.notes(),
 		"MailData": map[string]any{
 			"dayFormatted":       dateInTz.Format("Monday, January 2nd"),
-			"startTimeFormatted": fmt.Sprintf("%s AM", space.OpenTime),
-			"endTimeFormatted":   fmt.Sprintf("%s PM", space.CloseTime),
+			"startTimeFormatted": fmt.Sprintf("%s AM", startLocal.Format("15:04")),
+			"endTimeFormatted":   fmt.Sprintf("%s PM", endLocal.Format("15:04")),
 			"floorAddress":       "",
 			"locationAddress":    space.Location.Address.Line1,
 			"creditsUsed":        "2",
-			"Capacity":           "1",
+			"Capacity":           strconv.Itoa(opts.SeatCount()),
 			"TimezoneUsed":       fmt.Sprintf("GMT %s", space.Location.TimezoneOffset),
 			"TimezoneIana":       space.Location.TimeZone,
-			"startDateTime":      fmt.Sprintf("%s %s", dateInTz.Format("2006-01-02"), space.OpenTime),
-			"endDateTime":        fmt.Sprintf("%s %s", dateInTz.Format("2006-01-02"), space.CloseTime),
+			"startDateTime":      startLocal.Format("2006-01-02 15:04"),
+			"endDateTime":        endLocal.Format("2006-01-02 15:04"),
 			"locationName":       space.Location.Name,
 			"locationCity":       space.Location.Address.City,
 			"locationCountry":    space.Location.Address.Country,
 			"locationState":      space.Location.Address.State,
 		},
-		"LocationType":  params.LocationType,
 		"UTCOffset":     space.Location.TimezoneOffset,
 		"Currency":      "com.wework.credits",
 		"LocationID":    space.Location.UUID,
-		"SpaceID":       params.SpaceID,
 		"WeWorkSpaceID": space.UUID,
 		"StartTime":     startTime,
 		"EndTime":       endTime,
 	}
+	params.apply(quoteData)
+	if len(opts.Guests) > 0 {
+		quoteData["Guests"] = opts.Guests
+	}
+	if opts.multiday(date) {
+		quoteData["IsMultidayBooking"] = true
+	}
+	opts.setPayer(quoteData)
 
 	quoteResp, err := w.doRequest(http.MethodPost, quoteURL, quoteData)
 	if err != nil {
@@ -560,40 +580,21 @@ func (w *WeWork) getBookingQuote(date time.Time, space *Workspace) (*QuoteRespon
 	return &quote, nil
 }
 
-func (w *WeWork) createBooking(date time.Time, space *Workspace, quote *QuoteResponse) (*BookingResponse, error) {
-	loc, err := time.LoadLocation(space.Location.TimeZone)
+func (w *WeWork) createBooking(date time.Time, space *Workspace, quote *QuoteResponse, opts BookingOptions) (*BookingResponse, error) {
+	startLocal, endLocal, err := opts.Window(date, space)
 	if err != nil {
 		return nil, err
 	}
-
-	dateInTz := date.In(loc)
-	// Parse open and close times (e.g., "08:30" and "20:00")
-	openHour, openMin := 8, 30 // Default values
-	if len(space.OpenTime) >= 5 {
-		fmt.Sscanf(space.OpenTime, "%d:%d", &openHour, &openMin)
-	}
-	closeHour, closeMin := 20, 0 // Default values
-	if len(space.CloseTime) >= 5 {
-		fmt.Sscanf(space.CloseTime, "%d:%d", &closeHour, &closeMin)
-	}
-
-	// Create start and end times in local timezone
-	startLocal := time.Date(dateInTz.Year(), dateInTz.Month(), dateInTz.Day(), openHour, openMin, 0, 0, loc)
-	endLocal := time.Date(dateInTz.Year(), dateInTz.Month(), dateInTz.Day(), closeHour, closeMin, 0, 0, loc)
+	dateInTz := startLocal
 
 	// Convert to UTC
 	startTime := startLocal.UTC().Format("2006-01-02T15:04:05Z")
 	endTime := endLocal.UTC().Format("2006-01-02T15:04:05Z")
 
-	// Note: Removing the date adjustment logic that was causing "already booked" errors
-	// The API should handle far-future dates appropriately
-	if daysUntilBooking := time.Until(dateInTz); daysUntilBooking > 30*24*time.Hour {
-		// Don't print here - let the caller handle the warning
-		// The original date adjustment was causing issues by trying to book past dates
-	}
+	// Dates beyond the booking horizon are rejected by the API; see ParseHorizon
 
-	// Use LocationType-specific logic for booking SpaceID
-	bookingSpaceID := getBookingSpaceID(space)
+	// The region's strategy decides which IDs identify the space
+	strategy, _ := StrategyFor(space)
 
 	bookingURL := "https://members.wework.com/workplaceone/api/common-booking/"
 	bookingData := map[string]any{
@@ -602,34 +603,40 @@ func (w *WeWork) createBooking(date time.Time, space *Workspace, quote *QuoteRes
 		"SpaceType":            4,
 		"ReservationID":        "",
 		"TriggerCalendarEvent": true,
-		"Notes":                nil,
+		"Notes":                opts.notes(),
 		"MailData": map[string]any{
 			"dayFormatted":       dateInTz.Format("Monday, January 2nd"),
-			"startTimeFormatted": fmt.Sprintf("%s AM", space.OpenTime),
-			"endTimeFormatted":   fmt.Sprintf("%s PM", space.CloseTime),
+			"startTimeFormatted": fmt.Sprintf("%s AM", startLocal.Format("15:04")),
+			"endTimeFormatted":   fmt.Sprintf("%s PM", endLocal.Format("15:04")),
 			"floorAddress":       "",
 			"locationAddress":    space.Location.Address.Line1,
 			"creditsUsed":        "0",
-			"Capacity":           "1",
+			"Capacity":           strconv.Itoa(opts.SeatCount()),
 			"TimezoneUsed":       fmt.Sprintf("GMT %s", space.Location.TimezoneOffset),
 			"TimezoneIana":       space.Location.TimeZone,
-			"startDateTime":      fmt.Sprintf("%s %s", dateInTz.Format("2006-01-02"), space.OpenTime),
-			"endDateTime":        fmt.Sprintf("%s %s", dateInTz.Format("2006-01-02"), space.CloseTime),
+			"startDateTime":      startLocal.Format("2006-01-02 15:04"),
+			"endDateTime":        endLocal.Format("2006-01-02 15:04"),
 			"locationName":       space.Location.Name,
 			"locationCity":       space.Location.Address.City,
 			"locationCountry":    space.Location.Address.Country,
 			"locationState":      space.Location.Address.State,
 		},
-		"LocationType":  space.Location.AccountType, // Use dynamic LocationType from accountType
 		"UTCOffset":     space.Location.TimezoneOffset,
 		"Currency":      "com.wework.credits", // Currency field is required
 		"CreditRatio":   quote.GrandTotal.CreditRatio,
 		"LocationID":    space.Location.UUID,
-		"SpaceID":       bookingSpaceID, // Use LocationType-specific SpaceID
 		"WeWorkSpaceID": space.UUID,
 		"StartTime":     startTime,
 		"EndTime":       endTime,
 	}
+	strategy.BookingParameters(space).apply(bookingData)
+	if len(opts.Guests) > 0 {
+		bookingData["Guests"] = opts.Guests
+	}
+	if opts.multiday(date) {
+		bookingData["IsMultidayBooking"] = true
+	}
+	opts.setPayer(bookingData)
 
 	bookingResp, err := w.doRequest(http.MethodPost, bookingURL, bookingData)
 	if err != nil {
@@ -645,6 +652,42 @@ func (w *WeWork) createBooking(date time.Time, space *Workspace, quote *QuoteRes
 	return &result, nil
 }
 
+// CancelBooking cancels an existing booking.
+func (w *WeWork) CancelBooking(booking *Booking) (*CancelBookingResponse, error) {
+	if booking == nil {
+		return nil, fmt.Errorf("booking cannot be nil")
+	}
+
+	cancelURL := "https://members.wework.com/workplaceone/api/common-booking/cancel-booking"
+	cancelData := map[string]any{
+		"ApplicationType":              "WorkplaceOne",
+		"PlatformType":                 "iOS_APP",
+		"SpaceType":                    4,
+		"BookingUUID":                  booking.UUID,
+		"IsFromKube":                   booking.IsFromKube,
+		"KubeBookingExternalReference": booking.KubeBookingExternalReference,
+		"IsFromCwm":                    booking.IsFromCwm,
+		"CwmBookingReferenceId":        booking.CwmBookingReferenceID,
+	}
+
+	resp, err := w.doRequest(http.MethodPost, cancelURL, cancelData)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result CancelBookingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode cancel response: %v", err)
+	}
+
+	if len(result.Errors) > 0 {
+		return &result, fmt.Errorf("cancellation failed: %s", strings.Join(result.Errors, "; "))
+	}
+
+	return &result, nil
+}
+
 func (w *WeWork) GetCityDetails() ([]*CityDetailsResponse, error) {
 	url := "https://members.wework.com/workplaceone/api/wework-yardi/location/get-city-details"
 	resp, err := w.doRequest(http.MethodGet, url, nil)
@@ -1,9 +1,11 @@
 package wework
 
 import (
+	"encoding/json"
 	"slices"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestGetQuoteParameters(t *testing.T) {
@@ -179,3 +181,421 @@ func TestFindCityByFuzzyName(t *testing.T) {
 		})
 	}
 }
+
+func TestBookingOptionsWindow(t *testing.T) {
+	space := &Workspace{
+		OpenTime:  "08:30",
+		CloseTime: "20:00",
+		Location:  Location{TimeZone: "Asia/Tokyo"},
+	}
+	date := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		opts          BookingOptions
+		date          time.Time
+		expectedStart string
+		expectedEnd   string
+		errorContains string
+	}{
+		{
+			name:          "full day",
+			expectedStart: "2024-06-03 08:30 JST",
+			expectedEnd:   "2024-06-03 20:00 JST",
+		},
+		{
+			name:          "date parsed east of the location",
+			date:          time.Date(2024, 6, 3, 0, 0, 0, 0, time.FixedZone("UTC+14", 14*60*60)),
+			expectedStart: "2024-06-03 08:30 JST",
+			expectedEnd:   "2024-06-03 20:00 JST",
+		},
+		{
+			name:          "morning",
+			opts:          BookingOptions{StartTime: "09:00", EndTime: "13:00"},
+			expectedStart: "2024-06-03 09:00 JST",
+			expectedEnd:   "2024-06-03 13:00 JST",
+		},
+		{
+			name:          "until closing",
+			opts:          BookingOptions{StartTime: "14:00"},
+			expectedStart: "2024-06-03 14:00 JST",
+			expectedEnd:   "2024-06-03 20:00 JST",
+		},
+		{
+			name:          "end before start",
+			opts:          BookingOptions{StartTime: "13:00", EndTime: "09:00"},
+			errorContains: "not after start",
+		},
+		{
+			name:          "outside opening hours",
+			opts:          BookingOptions{StartTime: "07:00", EndTime: "09:00"},
+			errorContains: "outside opening hours",
+		},
+		{
+			name:          "invalid time",
+			opts:          BookingOptions{StartTime: "9am"},
+			errorContains: "invalid time",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := date
+			if !tt.date.IsZero() {
+				d = tt.date
+			}
+			start, end, err := tt.opts.Window(d, space)
+			if tt.errorContains != "" {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				if !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("expected error to contain '%s', got '%s'", tt.errorContains, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := start.Format("2006-01-02 15:04 MST"); got != tt.expectedStart {
+				t.Errorf("expected start %s, got %s", tt.expectedStart, got)
+			}
+			if got := end.Format("2006-01-02 15:04 MST"); got != tt.expectedEnd {
+				t.Errorf("expected end %s, got %s", tt.expectedEnd, got)
+			}
+		})
+	}
+}
+
+func TestBookingResponseStatus(t *testing.T) {
+	tests := []struct {
+		status          string
+		expectedSuccess bool
+		expectedPending bool
+	}{
+		{status: "BookingSuccess", expectedSuccess: true},
+		{status: "BookingPending", expectedPending: true},
+		{status: "PendingApproval", expectedPending: true},
+		{status: "AwaitingApproval", expectedPending: true},
+		{status: "pendingapproval", expectedPending: true},
+		{status: "ApprovalRejected"},
+		{status: "approval denied"},
+		{status: "PendingCancellation"},
+		{status: "BookingFailed"},
+		{status: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			resp := &BookingResponse{BookingStatus: tt.status}
+			if got := resp.IsSuccess(); got != tt.expectedSuccess {
+				t.Errorf("IsSuccess() = %v, want %v", got, tt.expectedSuccess)
+			}
+			if got := resp.IsPending(); got != tt.expectedPending {
+				t.Errorf("IsPending() = %v, want %v", got, tt.expectedPending)
+			}
+		})
+	}
+}
+
+func TestParseHorizon(t *testing.T) {
+	tests := []struct {
+		message      string
+		expectedOK   bool
+		expectedDays int
+	}{
+		{message: "Bookings can only be made up to 30 days in advance", expectedOK: true, expectedDays: 30},
+		{message: "You cannot book more than 14 days ahead", expectedOK: true, expectedDays: 14},
+		{message: "Selected date is outside the booking window", expectedOK: true},
+		{message: "API error: Date is too far in the future (Bad Request)", expectedOK: true},
+		{message: "No seats available"},
+		{message: "Insufficient credits for 2 days"},
+		{message: "Bookings for this date are not yet open", expectedOK: true},
+		{message: "You already have a booking on a future date"},
+		{message: "Please book ahead of your visit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			herr, ok := ParseHorizon(tt.message)
+			if ok != tt.expectedOK {
+				t.Fatalf("expected ok %v, got %v", tt.expectedOK, ok)
+			}
+			if ok && herr.Days != tt.expectedDays {
+				t.Errorf("expected %d days, got %d", tt.expectedDays, herr.Days)
+			}
+		})
+	}
+}
+
+func TestClassifyBookingError(t *testing.T) {
+	tests := []struct {
+		messages     []string
+		expectedCode string
+	}{
+		{messages: []string{"You already have a booking for this date"}, expectedCode: ErrCodeAlreadyBooked},
+		{messages: []string{"Insufficient credits to complete this booking"}, expectedCode: ErrCodeInsufficientCredits},
+		{messages: []string{"Bookings can only be made up to 30 days in advance"}, expectedCode: ErrCodeOutsideBookingWindow},
+		{messages: []string{"The location is closed on the selected date"}, expectedCode: ErrCodeLocationClosed},
+		{messages: []string{"Your membership does not include access to this location"}, expectedCode: ErrCodeMembershipNotAllowed},
+		{messages: []string{"", "Something unexpected happened"}, expectedCode: ErrCodeUnknown},
+		{messages: []string{"You already have a booking on a future date"}, expectedCode: ErrCodeAlreadyBooked},
+		{messages: []string{"Insufficient credits to book this far ahead"}, expectedCode: ErrCodeInsufficientCredits},
+		{messages: []string{"Booking not allowed more than 14 days in advance"}, expectedCode: ErrCodeOutsideBookingWindow},
+		{messages: []string{"This space is not available to your membership"}, expectedCode: ErrCodeMembershipNotAllowed},
+		{messages: []string{"Membership service temporarily unavailable"}, expectedCode: ErrCodeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.messages[len(tt.messages)-1], func(t *testing.T) {
+			berr := ClassifyBookingError(tt.messages...)
+			if berr.Code != tt.expectedCode {
+				t.Errorf("expected code %s, got %s", tt.expectedCode, berr.Code)
+			}
+			if berr.Code != ErrCodeUnknown && berr.Hint == "" {
+				t.Errorf("expected a hint for %s", berr.Code)
+			}
+			if berr.Message != strings.TrimSpace(strings.Join(tt.messages, " ")) {
+				t.Errorf("expected the message to be kept, got %q", berr.Message)
+			}
+		})
+	}
+}
+
+func TestMergeMultiday(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(day, hour int) CustomTime { return CustomTime{time.Date(2026, 3, day, hour, 0, 0, 0, tokyo)} }
+
+	bookings := []*Booking{
+		{UUID: "single", StartsAt: at(2, 9), EndsAt: at(2, 18)},
+		{UUID: "multi", StartsAt: at(3, 9), EndsAt: at(3, 18), IsMultidayBooking: true},
+		{UUID: "multi", StartsAt: at(4, 9), EndsAt: at(4, 18), IsMultidayBooking: true},
+		{UUID: "multi", StartsAt: at(5, 9), EndsAt: at(5, 18), IsMultidayBooking: true},
+		{UUID: "spanning", StartsAt: at(9, 9), EndsAt: at(10, 18), IsMultidayBooking: true},
+	}
+
+	merged := MergeMultiday(bookings)
+	var got []string
+	for _, b := range merged {
+		var days []string
+		for _, d := range b.Days() {
+			days = append(days, d.Format("02"))
+		}
+		got = append(got, b.UUID+":"+strings.Join(days, ","))
+	}
+	expected := []string{"single:02", "multi:03,04,05", "spanning:09,10"}
+	if !slices.Equal(got, expected) {
+		t.Errorf("MergeMultiday() = %v, want %v", got, expected)
+	}
+	if !bookings[1].EndsAt.Equal(at(3, 18).Time) {
+		t.Errorf("MergeMultiday() modified its input")
+	}
+}
+
+func TestSelectPayer(t *testing.T) {
+	var bootstrap AppBootstrapResponse
+	if err := json.Unmarshal([]byte(`{"weworkUserProfileData": {"profileData": {
+		"WeWorkCompanyList": [
+			{"CompanyUUID": "c-acme", "CompanyName": "Acme", "PreferredMembershipUUID": "m-all", "PreferredMembershipName": "All Access"},
+			{"CompanyUUID": "c-side", "CompanyName": "Side Project", "KUBECompanyUUID": "k-side"}
+		],
+		"WeWorkMembershipsList": [
+			{"uuid": "m-all", "accountUuid": "a-acme", "productName": "All Access"},
+			{"uuid": "m-day", "accountUuid": "a-day", "productName": "On Demand"},
+			{"uuid": "m-side", "accountUuid": "c-side", "productName": "Side Desk"}
+		]
+	}}}`), &bootstrap); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		company     string
+		membership  string
+		expected    Payer
+		expectedErr string
+	}{
+		{company: "acme", expected: Payer{CompanyUUID: "c-acme", CompanyName: "Acme", MembershipUUID: "m-all", MembershipName: "All Access"}},
+		{company: "c-side", membership: "Side Desk", expected: Payer{CompanyUUID: "c-side", CompanyName: "Side Project", MembershipUUID: "m-side", MembershipName: "Side Desk"}},
+		{company: "Acme", membership: "m-all", expected: Payer{CompanyUUID: "c-acme", CompanyName: "Acme", MembershipUUID: "m-all", MembershipName: "All Access"}},
+		{company: "c-side", membership: "On Demand", expectedErr: "membership 'On Demand' does not belong to company 'Side Project' (its memberships: Side Desk)"},
+		{company: "Acme", membership: "Side Desk", expectedErr: "membership 'Side Desk' does not belong to company 'Acme' (its memberships: All Access)"},
+		{membership: "m-day", expected: Payer{MembershipUUID: "m-day", MembershipName: "On Demand"}},
+		{company: "Globex", expectedErr: "company 'Globex' not found in your profile (available: Acme, Side Project)"},
+		{membership: "Dedicated Desk", expectedErr: "membership 'Dedicated Desk' not found in your profile (available: All Access, On Demand, Side Desk)"},
+	}
+
+	for _, tt := range tests {
+		got, err := bootstrap.SelectPayer(tt.company, tt.membership)
+		if tt.expectedErr != "" {
+			if err == nil || err.Error() != tt.expectedErr {
+				t.Errorf("SelectPayer(%q, %q) error = %v, want %q", tt.company, tt.membership, err, tt.expectedErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.expected {
+			t.Errorf("SelectPayer(%q, %q) = %+v, %v, want %+v", tt.company, tt.membership, got, err, tt.expected)
+		}
+	}
+
+	paidBy := func(account string) string {
+		payer, ok := bootstrap.PayerOf(&Booking{Order: Order{PaymentProfileUUID: account}})
+		if !ok {
+			return ""
+		}
+		return payer.String()
+	}
+	for account, expected := range map[string]string{
+		"a-acme":  "Acme (All Access)",
+		"m-day":   "On Demand",
+		"k-side":  "Side Project",
+		"unknown": "",
+		"":        "",
+	} {
+		if got := paidBy(account); got != expected {
+			t.Errorf("PayerOf(%q) = %q, want %q", account, got, expected)
+		}
+	}
+}
+
+func TestQuoteResponseUnmarshal(t *testing.T) {
+	payload := `{
+		"uuid": "q-1",
+		"grandTotal": {"currency": "com.wework.credits", "amount": 2.5, "creditRatio": 1200},
+		"subTotal": {"currency": "com.wework.credits", "amount": 3},
+		"lineItems": [{"name": "Hot Desk", "quantity": 2, "unitPrice": {"amount": 1.5}, "totalPrice": {"amount": 3}}, "unexpected"],
+		"taxes": [{"name": "VAT", "rate": 10, "amount": 0.3}],
+		"adjustments": [{"type": "discount", "amount": {"amount": -0.8}}],
+		"statusDetails": ["Quote valid", {"code": "OK"}],
+		"futureField": true
+	}`
+
+	var quote QuoteResponse
+	if err := json.Unmarshal([]byte(payload), &quote); err != nil {
+		t.Fatal(err)
+	}
+
+	if quote.UUID != "q-1" || quote.GrandTotal.Amount != 2.5 || quote.SubTotal.Amount != 3 {
+		t.Errorf("totals = %+v / %+v", quote.GrandTotal, quote.SubTotal)
+	}
+	if len(quote.LineItems) != 1 || quote.LineItems[0].Name != "Hot Desk" || quote.LineItems[0].TotalPrice.Amount != 3 {
+		t.Errorf("LineItems = %+v", quote.LineItems)
+	}
+	if len(quote.Taxes) != 1 || quote.Taxes[0].Amount.Amount != 0.3 {
+		t.Errorf("Taxes = %+v", quote.Taxes)
+	}
+	if len(quote.Adjustments) != 1 || quote.Adjustments[0].Amount.Amount != -0.8 {
+		t.Errorf("Adjustments = %+v", quote.Adjustments)
+	}
+	expectedDetails := []QuoteStatusDetail{{Message: "Quote valid"}, {Code: "OK"}}
+	if !slices.Equal(quote.StatusDetails, expectedDetails) {
+		t.Errorf("StatusDetails = %+v, want %+v", quote.StatusDetails, expectedDetails)
+	}
+	if !strings.Contains(string(quote.Raw), "futureField") {
+		t.Errorf("Raw does not keep the original payload: %s", quote.Raw)
+	}
+	if amount, ok := quote.GrandTotal.Equivalent(); !ok || amount != 3000 {
+		t.Errorf("Equivalent() = %v, %v, want 3000, true", amount, ok)
+	}
+}
+
+func TestBookingStrategies(t *testing.T) {
+	testCases := []struct {
+		name             string
+		workspace        *Workspace
+		expectedStrategy string
+		expectedMatched  bool
+		expectedQuoteID  string
+		expectedBookID   string
+	}{
+		{
+			name: "Munich - books by KubeId",
+			workspace: &Workspace{UUID: "munich-uuid", InventoryUUID: "munich-inventory",
+				Location: Location{AccountType: 2}, Reservable: &WorkspaceReservable{KubeId: "131834"}},
+			expectedStrategy: "kube",
+			expectedMatched:  true,
+			expectedQuoteID:  "munich-inventory",
+			expectedBookID:   "131834",
+		},
+		{
+			name: "Munich - no KubeId falls back to inventoryUuid",
+			workspace: &Workspace{UUID: "munich-uuid", InventoryUUID: "munich-inventory",
+				Location: Location{AccountType: 2}},
+			expectedStrategy: "kube",
+			expectedMatched:  true,
+			expectedQuoteID:  "munich-inventory",
+			expectedBookID:   "munich-inventory",
+		},
+		{
+			name: "Tokyo - inventoryUuid, not KubeId",
+			workspace: &Workspace{UUID: "tokyo-uuid", InventoryUUID: "tokyo-inventory",
+				Location: Location{AccountType: 4}, Reservable: &WorkspaceReservable{KubeId: "6147"}},
+			expectedStrategy: "inventory",
+			expectedMatched:  true,
+			expectedQuoteID:  "tokyo-inventory",
+			expectedBookID:   "tokyo-inventory",
+		},
+		{
+			name: "Bangkok - books by uuid",
+			workspace: &Workspace{UUID: "bangkok-uuid", InventoryUUID: "bangkok-inventory",
+				Location: Location{AccountType: 0}},
+			expectedStrategy: "uuid",
+			expectedMatched:  true,
+			expectedQuoteID:  "bangkok-inventory",
+			expectedBookID:   "bangkok-uuid",
+		},
+		{
+			name: "Unknown account type with KubeId - default",
+			workspace: &Workspace{UUID: "new-uuid", InventoryUUID: "new-inventory",
+				Location: Location{AccountType: 7}, Reservable: &WorkspaceReservable{KubeId: "98765", CwmSpaceId: 42}},
+			expectedStrategy: "default",
+			expectedMatched:  false,
+			expectedQuoteID:  "new-inventory",
+			expectedBookID:   "new-inventory",
+		},
+		{
+			name: "Unknown account type with CWM space ID - default",
+			workspace: &Workspace{UUID: "new-uuid", InventoryUUID: "new-inventory",
+				Location: Location{AccountType: 7}, Reservable: &WorkspaceReservable{CwmSpaceId: 42}},
+			expectedStrategy: "default",
+			expectedMatched:  false,
+			expectedQuoteID:  "new-inventory",
+			expectedBookID:   "new-inventory",
+		},
+		{
+			name: "Unknown account type without backend IDs - default",
+			workspace: &Workspace{UUID: "new-uuid",
+				Location: Location{AccountType: 7}},
+			expectedStrategy: "default",
+			expectedMatched:  false,
+			expectedQuoteID:  "new-uuid",
+			expectedBookID:   "new-uuid",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			strategy, matched := StrategyFor(tc.workspace)
+			if strategy.Name != tc.expectedStrategy || matched != tc.expectedMatched {
+				t.Fatalf("StrategyFor() = %s, %v, want %s, %v", strategy.Name, matched, tc.expectedStrategy, tc.expectedMatched)
+			}
+
+			quoteData := map[string]any{}
+			strategy.QuoteParameters(tc.workspace).apply(quoteData)
+			bookingData := map[string]any{}
+			strategy.BookingParameters(tc.workspace).apply(bookingData)
+
+			if quoteData["SpaceID"] != tc.expectedQuoteID {
+				t.Errorf("quote SpaceID = %v, want %s", quoteData["SpaceID"], tc.expectedQuoteID)
+			}
+			if bookingData["SpaceID"] != tc.expectedBookID {
+				t.Errorf("booking SpaceID = %v, want %s", bookingData["SpaceID"], tc.expectedBookID)
+			}
+			if bookingData["LocationType"] != tc.workspace.Location.AccountType {
+				t.Errorf("booking LocationType = %v, want %d", bookingData["LocationType"], tc.workspace.Location.AccountType)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package wework
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Stable codes for booking failures, for scripts to act on.
+const (
+	ErrCodeAlreadyBooked        = "already_booked"
+	ErrCodeInsufficientCredits  = "insufficient_credits"
+	ErrCodeOutsideBookingWindow = "outside_booking_window"
+	ErrCodeLocationClosed       = "location_closed"
+	ErrCodeMembershipNotAllowed = "membership_not_allowed"
+	ErrCodeUnknown              = "unknown"
+)
+
+// BookingError is a classified booking failure: a stable code, the API's message and a hint on how to fix it.
+type BookingError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+// bookingErrorPatterns map API messages to codes, checked in order. Outside booking window
+// messages are recognized by ParseHorizon, after these, since its keywords are less specific.
+var bookingErrorPatterns = []struct {
+	code string
+	re   *regexp.Regexp
+	hint string
+}{
+	{
+		code: ErrCodeAlreadyBooked,
+		re:   regexp.MustCompile(`(?i)already (?:been )?(?:booked|reserved)|already have a (?:booking|reservation)|overlapping|duplicate (?:booking|reservation)`),
+		hint: "You already have a booking that day; see `wework bookings`, or use --on-conflict replace",
+	},
+	{
+		code: ErrCodeInsufficientCredits,
+		re:   regexp.MustCompile(`(?i)insufficient|not enough credits|credit (?:limit|balance)|out of credits|exceeds? .*credits`),
+		hint: "Not enough credits left this month; check your balance in the app or book fewer seats",
+	},
+	{
+		code: ErrCodeLocationClosed,
+		re:   regexp.MustCompile(`(?i)\bclosed\b|not open on|outside (?:of )?(?:operating|opening|business) hours|holiday`),
+		hint: "The location is closed at that time; pick another date or check its hours with `wework info`",
+	},
+	{
+		code: ErrCodeMembershipNotAllowed,
+		re:   regexp.MustCompile(`(?i)your (?:membership|plan) (?:does not|doesn't|do not|cannot|can't|is not|isn't)|not (?:included in|covered by|part of|available (?:for|to|on)) your (?:membership|plan|account)|no access to this (?:location|building|space)|access (?:to this (?:location|building) )?(?:is )?denied|not (?:allowed|permitted|eligible|entitled) to (?:book|access|use) (?:this|that) (?:location|building|space)`),
+		hint: "Your membership can't book this location; pick a location your membership covers",
+	},
+}
+
+// ClassifyBookingError classifies the messages of a failed quote or booking. Messages that
+// aren't recognized get ErrCodeUnknown and are kept as they are.
+func ClassifyBookingError(messages ...string) BookingError {
+	var kept []string
+	for _, m := range messages {
+		if m = strings.TrimSpace(m); m != "" {
+			kept = append(kept, m)
+		}
+	}
+	message := strings.Join(kept, "; ")
+
+	for _, m := range kept {
+		for _, p := range bookingErrorPatterns {
+			if p.re.MatchString(m) {
+				return BookingError{Code: p.code, Message: message, Hint: p.hint}
+			}
+		}
+	}
+	if herr, ok := ParseHorizon(kept...); ok {
+		hint := "The date is further ahead than the location accepts bookings; use `book --when-open` to book it once bookings open"
+		if herr.Days > 0 {
+			hint = fmt.Sprintf("Bookings open %d days ahead; use `book --when-open` to book it once bookings open", herr.Days)
+		}
+		return BookingError{Code: ErrCodeOutsideBookingWindow, Message: message, Hint: hint}
+	}
+	return BookingError{Code: ErrCodeUnknown, Message: message}
+}
+
+// ErrorMessages returns the errors of a failed booking, or its status when it has none.
+func (r *BookingResponse) ErrorMessages() []string {
+	if len(r.Errors) == 0 {
+		return []string{r.BookingStatus}
+	}
+	return r.Errors
+}
@@ -0,0 +1,43 @@
+package wework
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	horizonDaysRe     = regexp.MustCompile(`(?i)(\d+)\s*(?:calendar\s+)?days?\b`)
+	horizonKeywordsRe = regexp.MustCompile(`(?i)in advance|days? ahead|(?:too|that|so) far|far (?:ahead|in(?:to)? the future)|beyond (?:the )?(?:booking|reservation) (?:window|horizon)|beyond \d+ days|booking (?:window|horizon)|not (?:yet )?open for (?:booking|reservation)s?|not yet open|outside .*window`)
+)
+
+// HorizonError reports a booking for a date further ahead than the location accepts.
+// Days is how many days ahead bookings open, or 0 if the message doesn't say.
+type HorizonError struct {
+	Days    int
+	Message string
+}
+
+func (e *HorizonError) Error() string {
+	if e.Days > 0 {
+		return fmt.Sprintf("date is beyond the booking horizon of %d days: %s", e.Days, e.Message)
+	}
+	return fmt.Sprintf("date is beyond the booking horizon: %s", e.Message)
+}
+
+// ParseHorizon recognizes API messages rejecting a date as too far ahead, such as
+// "Bookings can only be made up to 30 days in advance".
+func ParseHorizon(messages ...string) (*HorizonError, bool) {
+	for _, message := range messages {
+		if !horizonKeywordsRe.MatchString(message) {
+			continue
+		}
+		herr := &HorizonError{Message: strings.TrimSpace(message)}
+		if m := horizonDaysRe.FindStringSubmatch(message); m != nil {
+			herr.Days, _ = strconv.Atoi(m[1])
+		}
+		return herr, true
+	}
+	return nil, false
+}
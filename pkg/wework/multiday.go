@@ -0,0 +1,50 @@
+package wework
+
+import "time"
+
+// Days returns the calendar days a booking covers in its location's timezone: the start day
+// for a regular booking, every day from start to end for a multi-day booking.
+func (b *Booking) Days() []time.Time {
+	if b.StartsAt.IsZero() {
+		return nil
+	}
+	start := b.StartsAt.Time
+	first := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	if !b.IsMultidayBooking || !b.EndsAt.After(start) {
+		return []time.Time{first}
+	}
+
+	end := b.EndsAt.Time.In(start.Location())
+	var days []time.Time
+	for d := first; !d.After(end); d = d.AddDate(0, 0, 1) {
+		days = append(days, d)
+	}
+	return days
+}
+
+// MergeMultiday merges multi-day bookings that are listed once per day, with the same UUID,
+// into a single booking spanning all of its days. Other bookings are kept in order.
+func MergeMultiday(bookings []*Booking) []*Booking {
+	merged := make([]*Booking, 0, len(bookings))
+	byUUID := make(map[string]*Booking)
+	for _, b := range bookings {
+		if b == nil || !b.IsMultidayBooking || b.UUID == "" {
+			merged = append(merged, b)
+			continue
+		}
+		first, ok := byUUID[b.UUID]
+		if !ok {
+			copied := *b
+			byUUID[b.UUID] = &copied
+			merged = append(merged, &copied)
+			continue
+		}
+		if b.StartsAt.Before(first.StartsAt.Time) {
+			first.StartsAt = b.StartsAt
+		}
+		if b.EndsAt.After(first.EndsAt.Time) {
+			first.EndsAt = b.EndsAt
+		}
+	}
+	return merged
+}
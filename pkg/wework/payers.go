@@ -0,0 +1,117 @@
+package wework
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Payer is the company and membership a booking is charged to.
+type Payer struct {
+	CompanyUUID    string `json:"companyUUID,omitempty"`
+	CompanyName    string `json:"companyName,omitempty"`
+	MembershipUUID string `json:"membershipUUID,omitempty"`
+	MembershipName string `json:"membershipName,omitempty"`
+}
+
+func (p Payer) String() string {
+	switch {
+	case p.CompanyName != "" && p.MembershipName != "":
+		return fmt.Sprintf("%s (%s)", p.CompanyName, p.MembershipName)
+	case p.CompanyName != "":
+		return p.CompanyName
+	case p.MembershipName != "":
+		return p.MembershipName
+	case p.CompanyUUID != "":
+		return p.CompanyUUID
+	}
+	return p.MembershipUUID
+}
+
+// SelectPayer finds the company and membership, each given by UUID or name, among those in the
+// member's profile. With only a company, the company's preferred membership is used. With both,
+// the membership must belong to the company: be its preferred membership, or be held on its
+// account.
+func (b *AppBootstrapResponse) SelectPayer(company, membership string) (Payer, error) {
+	profile := b.WeworkUserProfileData.ProfileData
+	var payer Payer
+	var companyAccounts []string
+
+	if company != "" {
+		var names []string
+		for _, c := range profile.WeWorkCompanyList {
+			names = append(names, c.CompanyName)
+			if c.CompanyUUID == company || strings.EqualFold(c.CompanyName, company) {
+				payer = Payer{
+					CompanyUUID:    c.CompanyUUID,
+					CompanyName:    c.CompanyName,
+					MembershipUUID: c.PreferredMembershipUUID,
+					MembershipName: c.PreferredMembershipName,
+				}
+				companyAccounts = []string{c.CompanyUUID, c.KUBECompanyUUID}
+				break
+			}
+		}
+		if payer.CompanyUUID == "" {
+			return Payer{}, fmt.Errorf("company '%s' not found in your profile (available: %s)", company, strings.Join(names, ", "))
+		}
+	}
+
+	if membership != "" {
+		// The preferred membership is looked up before it's replaced below
+		belongs := func(uuid, account string) bool {
+			return uuid == payer.MembershipUUID || (account != "" && slices.Contains(companyAccounts, account))
+		}
+		var names, companyNames []string
+		for _, m := range profile.WeWorkMembershipsList {
+			names = append(names, m.ProductName)
+			if belongs(m.UUID, m.AccountUUID) {
+				companyNames = append(companyNames, m.ProductName)
+			}
+		}
+		for _, m := range profile.WeWorkMembershipsList {
+			if m.UUID != membership && !strings.EqualFold(m.ProductName, membership) {
+				continue
+			}
+			if payer.CompanyUUID != "" && !belongs(m.UUID, m.AccountUUID) {
+				return Payer{}, fmt.Errorf("membership '%s' does not belong to company '%s' (its memberships: %s)", membership, payer.CompanyName, strings.Join(companyNames, ", "))
+			}
+			payer.MembershipUUID = m.UUID
+			payer.MembershipName = m.ProductName
+			return payer, nil
+		}
+		return Payer{}, fmt.Errorf("membership '%s' not found in your profile (available: %s)", membership, strings.Join(names, ", "))
+	}
+	return payer, nil
+}
+
+// PayerOf returns the company or membership whose account a booking was charged to, matched by
+// the order's payment profile. It returns false when the payment profile isn't recognized.
+func (b *AppBootstrapResponse) PayerOf(booking *Booking) (Payer, bool) {
+	profile := b.WeworkUserProfileData.ProfileData
+	account := booking.Order.PaymentProfileUUID
+	if account == "" {
+		return Payer{}, false
+	}
+
+	for _, m := range profile.WeWorkMembershipsList {
+		if m.UUID != account && m.AccountUUID != account {
+			continue
+		}
+		payer := Payer{MembershipUUID: m.UUID, MembershipName: m.ProductName}
+		for _, c := range profile.WeWorkCompanyList {
+			if c.PreferredMembershipUUID == m.UUID {
+				payer.CompanyUUID = c.CompanyUUID
+				payer.CompanyName = c.CompanyName
+				break
+			}
+		}
+		return payer, true
+	}
+	for _, c := range profile.WeWorkCompanyList {
+		if c.CompanyUUID == account || c.KUBECompanyUUID == account {
+			return Payer{CompanyUUID: c.CompanyUUID, CompanyName: c.CompanyName}, true
+		}
+	}
+	return Payer{}, false
+}
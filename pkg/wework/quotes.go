@@ -0,0 +1,152 @@
+package wework
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// UnmarshalJSON decodes a quote, keeping the raw payload. The breakdown (line items, taxes,
+// adjustments and status details) is decoded leniently: a part in an unexpected shape is left
+// empty rather than failing the quote.
+func (q *QuoteResponse) UnmarshalJSON(b []byte) error {
+	type plain QuoteResponse
+	aux := struct {
+		*plain
+		StatusDetails json.RawMessage `json:"statusDetails"`
+		Taxes         json.RawMessage `json:"taxes"`
+		LineItems     json.RawMessage `json:"lineItems"`
+		Adjustments   json.RawMessage `json:"adjustments"`
+	}{plain: (*plain)(q)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	q.StatusDetails, q.Taxes, q.LineItems, q.Adjustments = nil, nil, nil, nil
+	decodeLenient(aux.StatusDetails, &q.StatusDetails)
+	decodeLenient(aux.Taxes, &q.Taxes)
+	decodeLenient(aux.LineItems, &q.LineItems)
+	decodeLenient(aux.Adjustments, &q.Adjustments)
+	q.Raw = append(json.RawMessage(nil), b...)
+	return nil
+}
+
+// decodeLenient decodes each element of a JSON array into items, skipping elements it can't decode.
+func decodeLenient[T any](raw json.RawMessage, items *[]T) {
+	var elems []json.RawMessage
+	if len(raw) == 0 || json.Unmarshal(raw, &elems) != nil {
+		return
+	}
+	for _, elem := range elems {
+		var item T
+		if json.Unmarshal(elem, &item) == nil {
+			*items = append(*items, item)
+		}
+	}
+}
+
+// UnmarshalJSON accepts a status detail given as a plain message as well as an object.
+func (d *QuoteStatusDetail) UnmarshalJSON(b []byte) error {
+	var message string
+	if json.Unmarshal(b, &message) == nil {
+		*d = QuoteStatusDetail{Message: message}
+		return nil
+	}
+	type plain QuoteStatusDetail
+	return json.Unmarshal(b, (*plain)(d))
+}
+
+// UnmarshalJSON accepts a bare amount as well as an object with a currency.
+func (m *Money) UnmarshalJSON(b []byte) error {
+	var amount float64
+	if json.Unmarshal(b, &amount) == nil {
+		*m = Money{Amount: amount}
+		return nil
+	}
+	type plain Money
+	return json.Unmarshal(b, (*plain)(m))
+}
+
+// Equivalent returns the amount in the location's currency, using the credit ratio. It returns
+// false when the quote has no credit ratio.
+func (m Money) Equivalent() (float64, bool) {
+	if m.CreditRatio <= 0 {
+		return 0, false
+	}
+	return m.Amount * m.CreditRatio, true
+}
+
+// QuoteLifetime is how long a saved quote is considered valid. The API doesn't report when a
+// quote expires, so this errs on the short side.
+const QuoteLifetime = 15 * time.Minute
+
+// SavedQuote is a quote kept for booking later, with the space, window and options it was made for.
+type SavedQuote struct {
+	Quote        *QuoteResponse `json:"quote"`
+	Date         string         `json:"date"`
+	LocationUUID string         `json:"locationUUID"`
+	LocationName string         `json:"locationName"`
+	SpaceUUID    string         `json:"spaceUUID"`
+	TimeZone     string         `json:"timeZone"`
+	// StartTime and EndTime are the quoted window ("15:04" in the location's timezone).
+	StartTime      string  `json:"startTime"`
+	EndTime        string  `json:"endTime"`
+	Seats          int     `json:"seats"`
+	Guests         []Guest `json:"guests,omitempty"`
+	CompanyUUID    string  `json:"companyUUID,omitempty"`
+	MembershipUUID string  `json:"membershipUUID,omitempty"`
+	// ListedCredits is the space's listed price when quoted, for information.
+	ListedCredits float64   `json:"listedCredits,omitempty"`
+	QuotedAt      time.Time `json:"quotedAt"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+}
+
+// NewSavedQuote records quote for space on date with opts, as quoted at now.
+func NewSavedQuote(date time.Time, space *Workspace, opts BookingOptions, quote *QuoteResponse, now time.Time) (*SavedQuote, error) {
+	start, end, err := opts.Window(date, space)
+	if err != nil {
+		return nil, err
+	}
+	return &SavedQuote{
+		Quote:          quote,
+		Date:           start.Format("2006-01-02"),
+		LocationUUID:   space.Location.UUID,
+		LocationName:   space.Location.Name,
+		SpaceUUID:      space.UUID,
+		TimeZone:       space.Location.TimeZone,
+		StartTime:      start.Format("15:04"),
+		EndTime:        end.Format("15:04"),
+		Seats:          opts.SeatCount(),
+		Guests:         opts.Guests,
+		CompanyUUID:    opts.CompanyUUID,
+		MembershipUUID: opts.MembershipUUID,
+		QuotedAt:       now,
+		ExpiresAt:      now.Add(QuoteLifetime),
+	}, nil
+}
+
+// Options returns the booking options the quote was made for.
+func (q *SavedQuote) Options() BookingOptions {
+	return BookingOptions{
+		Seats:          q.Seats,
+		Guests:         q.Guests,
+		StartTime:      q.StartTime,
+		EndTime:        q.EndTime,
+		CompanyUUID:    q.CompanyUUID,
+		MembershipUUID: q.MembershipUUID,
+	}
+}
+
+// Day returns the quoted date in the location's timezone.
+func (q *SavedQuote) Day() (time.Time, error) {
+	loc, err := time.LoadLocation(q.TimeZone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to load timezone %s: %v", q.TimeZone, err)
+	}
+	return time.ParseInLocation("2006-01-02", q.Date, loc)
+}
+
+// Expired reports whether the quote is too old to book at now.
+func (q *SavedQuote) Expired(now time.Time) bool {
+	return !now.Before(q.ExpiresAt)
+}
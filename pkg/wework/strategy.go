@@ -0,0 +1,127 @@
+package wework
+
+import "fmt"
+
+// StrategyKey is what decides how a workspace is quoted and booked: the account type of its
+// location and which backend IDs it carries.
+type StrategyKey struct {
+	AccountType int  `json:"accountType"`
+	Kube        bool `json:"kube"` // the space has a KubeId
+	CWM         bool `json:"cwm"`  // the space has a CWM space ID
+}
+
+// KeyOf returns the strategy key of a workspace.
+func KeyOf(space *Workspace) StrategyKey {
+	key := StrategyKey{AccountType: space.Location.AccountType}
+	if space.Reservable != nil {
+		key.Kube = space.Reservable.KubeId != ""
+		key.CWM = space.Reservable.CwmSpaceId != 0
+	}
+	return key
+}
+
+// BookingStrategy is how one group of locations identifies spaces in quote and booking
+// payloads. Different WeWork regions/systems use different ID fields.
+type BookingStrategy struct {
+	Name string
+	// Example names a region known to use the strategy, for diagnostics.
+	Example string
+	// Match reports whether the strategy handles workspaces with key.
+	Match func(key StrategyKey) bool
+	// QuoteSpaceID and BookingSpaceID return the SpaceID to send when quoting and booking.
+	QuoteSpaceID   func(space *Workspace) string
+	BookingSpaceID func(space *Workspace) string
+}
+
+// QuoteParameters returns the LocationType and SpaceID for quoting space.
+func (s *BookingStrategy) QuoteParameters(space *Workspace) QuoteParameters {
+	return QuoteParameters{LocationType: space.Location.AccountType, SpaceID: s.QuoteSpaceID(space)}
+}
+
+// BookingParameters returns the LocationType and SpaceID for booking space.
+func (s *BookingStrategy) BookingParameters(space *Workspace) QuoteParameters {
+	return QuoteParameters{LocationType: space.Location.AccountType, SpaceID: s.BookingSpaceID(space)}
+}
+
+// apply sets the parameters in a quote or booking payload.
+func (p QuoteParameters) apply(data map[string]any) {
+	data["LocationType"] = p.LocationType
+	data["SpaceID"] = p.SpaceID
+}
+
+// inventoryOrUUID prefers the inventory UUID, falling back to the UUID when it's empty.
+func inventoryOrUUID(space *Workspace) string {
+	if space.InventoryUUID != "" {
+		return space.InventoryUUID
+	}
+	return space.UUID
+}
+
+// Account types with a known strategy. Spaces of other account types get the default strategy,
+// since how they are booked hasn't been seen; their Kube and CWM IDs are shown by diagnostics.
+const (
+	accountTypeUUID      = 0
+	accountTypeKube      = 2
+	accountTypeInventory = 4
+)
+
+// kubeIDOrInventory prefers the KubeId, falling back to the inventory UUID or UUID.
+func kubeIDOrInventory(space *Workspace) string {
+	if space.Reservable != nil && space.Reservable.KubeId != "" {
+		return space.Reservable.KubeId
+	}
+	return inventoryOrUUID(space)
+}
+
+// bookingStrategies are checked in order; the first match is used.
+var bookingStrategies = []*BookingStrategy{
+	{
+		Name:           "kube",
+		Example:        "Munich",
+		Match:          func(key StrategyKey) bool { return key.AccountType == accountTypeKube },
+		QuoteSpaceID:   inventoryOrUUID,
+		BookingSpaceID: kubeIDOrInventory,
+	},
+	{
+		// Tokyo spaces carry a KubeId too, but are booked by inventory UUID
+		Name:           "inventory",
+		Example:        "Tokyo",
+		Match:          func(key StrategyKey) bool { return key.AccountType == accountTypeInventory },
+		QuoteSpaceID:   inventoryOrUUID,
+		BookingSpaceID: inventoryOrUUID,
+	},
+	{
+		Name:           "uuid",
+		Example:        "Bangkok",
+		Match:          func(key StrategyKey) bool { return key.AccountType == accountTypeUUID },
+		QuoteSpaceID:   inventoryOrUUID,
+		BookingSpaceID: func(space *Workspace) string { return space.UUID },
+	},
+}
+
+// defaultStrategy is used for spaces no strategy matches, i.e. of an unknown account type.
+var defaultStrategy = &BookingStrategy{
+	Name:           "default",
+	QuoteSpaceID:   inventoryOrUUID,
+	BookingSpaceID: inventoryOrUUID,
+}
+
+// StrategyFor returns the strategy for space, and false when none matched and the default is used.
+func StrategyFor(space *Workspace) (*BookingStrategy, bool) {
+	key := KeyOf(space)
+	for _, s := range bookingStrategies {
+		if s.Match(key) {
+			return s, true
+		}
+	}
+	return defaultStrategy, false
+}
+
+// getQuoteParameters determines the correct LocationType and SpaceID for a quote.
+func getQuoteParameters(space *Workspace) (QuoteParameters, error) {
+	if space == nil {
+		return QuoteParameters{}, fmt.Errorf("workspace cannot be nil")
+	}
+	s, _ := StrategyFor(space)
+	return s.QuoteParameters(space), nil
+}
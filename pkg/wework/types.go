@@ -1,6 +1,7 @@
 package wework
 
 import (
+	"encoding/json"
 	"strings"
 	"time"
 )
@@ -38,6 +39,40 @@ type BookingResponse struct {
 	ReservationID string   `json:"ReservationID"`
 }
 
+// BookingStatusSuccess is the BookingStatus of a confirmed booking.
+const BookingStatusSuccess = "BookingSuccess"
+
+// IsSuccess reports whether the booking was confirmed right away.
+func (r *BookingResponse) IsSuccess() bool {
+	return r.BookingStatus == BookingStatusSuccess
+}
+
+// pendingBookingStatuses are the BookingStatus values of a booking that was accepted but awaits
+// confirmation or approval. Statuses such as "ApprovalRejected" are deliberately not matched.
+var pendingBookingStatuses = []string{
+	"BookingPending",
+	"PendingApproval",
+	"AwaitingApproval",
+	"ApprovalPending",
+	"PendingConfirmation",
+}
+
+// IsPending reports whether the booking was accepted but awaits confirmation or approval,
+// e.g. at locations with booking approval turned on.
+func (r *BookingResponse) IsPending() bool {
+	for _, status := range pendingBookingStatuses {
+		if strings.EqualFold(r.BookingStatus, status) {
+			return true
+		}
+	}
+	return false
+}
+
+type CancelBookingResponse struct {
+	BookingStatus string   `json:"BookingStatus"`
+	Errors        []string `json:"Errors"`
+}
+
 type GeoLocation struct {
 	UUID                 string  `json:"uuid"`
 	Name                 string  `json:"name"`
@@ -75,11 +110,85 @@ type Booking struct {
 	IsBookingConfirmationPending bool             `json:"isBookingConfirmationPending"`
 	IsBookingApprovalOn          bool             `json:"IsBookingApprovalOn"`
 	SameDayCancelPolicy          bool             `json:"sameDayCancelPolicy"`
+	Guests                       []Guest          `json:"guests,omitempty"`
+	Notes                        string           `json:"notes,omitempty"`
 	// KubeCreatedOnDate            *time.Time      `json:"kubeCreatedOnDate,omitempty"`
 	// KubeModifiedOnDate           *time.Time      `json:"kubeModifiedOnDate,omitempty"`
 	// KubeStartDate                *time.Time      `json:"kubeStartDate,omitempty"`
 }
 
+// Guest is an additional attendee brought along on a booking.
+type Guest struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// BookingOptions customises a quote or booking beyond a single-seat, full-day reservation.
+type BookingOptions struct {
+	// Seats is the number of seats to reserve, including the member's own. Zero means one.
+	Seats  int
+	Guests []Guest
+	// Note is attached to the reservation, e.g. a project code for expense tracking.
+	Note string
+	// StartTime and EndTime ("15:04" in the location's timezone) book only part of the day.
+	// Empty means the space's opening or closing time.
+	StartTime string
+	EndTime   string
+	// EndDate makes the booking a single multi-day reservation from the booked date through
+	// EndDate. Zero, or the booked date itself, books a single day.
+	EndDate time.Time
+	// CompanyUUID and MembershipUUID charge the booking to a specific company and membership
+	// of the member. Empty means the server's default.
+	CompanyUUID    string
+	MembershipUUID string
+}
+
+// SeatCount returns the number of seats to request.
+func (o BookingOptions) SeatCount() int {
+	if o.Seats < 1 {
+		return 1
+	}
+	return o.Seats
+}
+
+// multiday reports whether the booking starting on date spans several days.
+func (o BookingOptions) multiday(date time.Time) bool {
+	return !o.EndDate.IsZero() && o.EndDate.Format("2006-01-02") > date.Format("2006-01-02")
+}
+
+// notes returns the Notes payload value, which the API expects to be null when unset.
+func (o BookingOptions) notes() any {
+	if o.Note == "" {
+		return nil
+	}
+	return o.Note
+}
+
+// setPayer adds the company and membership to charge to a quote or booking payload, when set.
+func (o BookingOptions) setPayer(data map[string]any) {
+	if o.CompanyUUID != "" {
+		data["CompanyUUID"] = o.CompanyUUID
+	}
+	if o.MembershipUUID != "" {
+		data["MembershipUUID"] = o.MembershipUUID
+	}
+}
+
+// Booking states reported by Booking.Status.
+const (
+	BookingConfirmed = "confirmed"
+	BookingPending   = "pending"
+)
+
+// Status returns BookingPending while the booking awaits confirmation or approval and
+// BookingConfirmed otherwise.
+func (b *Booking) Status() string {
+	if b.IsBookingConfirmationPending {
+		return BookingPending
+	}
+	return BookingConfirmed
+}
+
 type CreditOrder struct {
 	Price string `json:"price"`
 }
@@ -244,14 +353,17 @@ type Seat struct {
 }
 
 type QuoteResponse struct {
-	UUID          string     `json:"uuid"`
-	QuoteStatus   int        `json:"quoteStatus"`
-	StatusDetails []any      `json:"statusDetails"`
-	GrandTotal    Money      `json:"grandTotal"`
-	SubTotal      Money      `json:"subTotal"`
-	Taxes         []any      `json:"taxes"`
-	LineItems     []LineItem `json:"lineItems"`
-	Adjustments   []any      `json:"adjustments"`
+	UUID          string              `json:"uuid"`
+	QuoteStatus   int                 `json:"quoteStatus"`
+	StatusDetails []QuoteStatusDetail `json:"statusDetails"`
+	GrandTotal    Money               `json:"grandTotal"`
+	SubTotal      Money               `json:"subTotal"`
+	Taxes         []Tax               `json:"taxes"`
+	LineItems     []LineItem          `json:"lineItems"`
+	Adjustments   []Adjustment        `json:"adjustments"`
+
+	// Raw is the quote as returned by the API, including fields not modelled here.
+	Raw json.RawMessage `json:"-"`
 }
 
 type Money struct {
@@ -260,7 +372,36 @@ type Money struct {
 	CreditRatio float64 `json:"creditRatio,omitempty"`
 }
 
-type LineItem struct{}
+// LineItem is a charge on a quote, e.g. the seats booked.
+type LineItem struct {
+	UUID        string  `json:"uuid,omitempty"`
+	Name        string  `json:"name,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Quantity    float64 `json:"quantity,omitempty"`
+	UnitPrice   Money   `json:"unitPrice"`
+	TotalPrice  Money   `json:"totalPrice"`
+}
+
+// Tax is a tax charged on a quote.
+type Tax struct {
+	Name   string  `json:"name,omitempty"`
+	Rate   float64 `json:"rate,omitempty"`
+	Amount Money   `json:"amount"`
+}
+
+// Adjustment is a discount or surcharge on a quote; discounts have a negative amount.
+type Adjustment struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Amount      Money  `json:"amount"`
+}
+
+// QuoteStatusDetail explains the status of a quote, e.g. why it can't be booked.
+type QuoteStatusDetail struct {
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
 
 type AppBootstrapRequest struct {
 	IsCAKube         bool   `json:"isCAKube"`